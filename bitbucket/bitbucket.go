@@ -27,47 +27,78 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 
 	bitbucket "github.com/ktrysmt/go-bitbucket"
+
+	"github.com/isindir/git-get/credentials"
+	"github.com/isindir/git-get/metadata"
+	"github.com/isindir/git-get/transport"
 )
 
+// publicHost - Bitbucket Cloud host
+const publicHost = "bitbucket.org"
+
 type GitGetBitbucket struct {
 	username string
 	token    string
+	// API client is created once and shared by concurrent calls
+	mutex  sync.Mutex
+	client *bitbucket.Client
 }
 
 type GitGetBitbucketI interface {
 	Init() bool
+	InitForHost(host string) bool
 	RepositoryExists(repoSha, owner, repository string) bool
 	CreateRepository(repoSha, repository, mirrorVisibilityMode, sourceURL, projectName string) *bitbucket.Repository
+	GetRepositoryMetadata(repoSha, owner, repository string) (metadata.Repository, error)
+	UpdateRepositoryMetadata(repoSha, owner, repository string, current, desired metadata.Repository) error
 	FetchOwnerRepos(repoSha, owner, bitbucketRole string) []bitbucket.Repository
 }
 
+// Init - reads credentials for bitbucket.org, see InitForHost
 func (gitProvider *GitGetBitbucket) Init() bool {
-	var usernameFound, tokenFound bool
-	gitProvider.username, usernameFound = os.LookupEnv("BITBUCKET_USERNAME")
-	if !usernameFound {
-		log.Fatal("Error - environment variable BITBUCKET_USERNAME not found")
+	return gitProvider.InitForHost(publicHost)
+}
+
+// InitForHost - reads username and token (password) of the host from credentials hosts file
+// or BITBUCKET_USERNAME and BITBUCKET_TOKEN
+func (gitProvider *GitGetBitbucket) InitForHost(host string) bool {
+	hostCredentials, err := credentials.Lookup(host, "BITBUCKET_USERNAME", "BITBUCKET_TOKEN")
+	if err != nil {
+		log.Fatalf("Error - Bitbucket credentials for '%s': %s", host, err)
 		os.Exit(1)
 	}
-
-	gitProvider.token, tokenFound = os.LookupEnv("BITBUCKET_TOKEN")
-	if !tokenFound {
-		log.Fatal("Error - environment variable BITBUCKET_TOKEN not found")
+	if hostCredentials.Username == "" {
+		log.Fatalf("Error - Bitbucket username for '%s' not found (environment variable BITBUCKET_USERNAME)", host)
 		os.Exit(1)
 	}
+	gitProvider.username = hostCredentials.Username
+	gitProvider.token = hostCredentials.Token
+	gitProvider.client = nil
 
-	return usernameFound && tokenFound
+	return true
 }
 
+// auth - returns API client, client is created on first use and reused afterwards
 func (gitProvider *GitGetBitbucket) auth(repoSha string) *bitbucket.Client {
+	gitProvider.mutex.Lock()
+	defer gitProvider.mutex.Unlock()
+
+	if gitProvider.client != nil {
+		return gitProvider.client
+	}
+
 	git, err := bitbucket.NewBasicAuth(gitProvider.username, gitProvider.token)
 	if err != nil {
 		log.Fatalf("%s: Error - authentication failed", repoSha)
 		os.Exit(1)
 	}
+	git.HttpClient = transport.Client()
+	gitProvider.client = git
 
 	return git
 }
@@ -96,13 +127,6 @@ func (gitProvider *GitGetBitbucket) RepositoryExists(repoSha, owner, repository
 	return true
 }
 
-// RepositoryExists - checks if bitbucket repository exists (package function for backward compatibility)
-func RepositoryExists(repoSha, owner, repository string) bool {
-	gitProvider := &GitGetBitbucket{}
-	gitProvider.Init()
-	return gitProvider.RepositoryExists(repoSha, owner, repository)
-}
-
 // ProjectExists - checks if bitbucket project exists
 func ProjectExists(git *bitbucket.Client, repoSha, workspace, project string) bool {
 	opt := &bitbucket.ProjectOptions{
@@ -160,46 +184,116 @@ func (gitProvider *GitGetBitbucket) CreateRepository(repoSha, repository, mirror
 	return resultingRepository
 }
 
-// CreateRepository - create bitbucket repository (package function for backward compatibility)
-func CreateRepository(repoSha, repository, mirrorVisibilityMode, sourceURL, projectName string) *bitbucket.Repository {
-	gitProvider := &GitGetBitbucket{}
-	gitProvider.Init()
-	return gitProvider.CreateRepository(repoSha, repository, mirrorVisibilityMode, sourceURL, projectName)
+// GetRepositoryMetadata - returns metadata of bitbucket repository, Bitbucket repositories have
+// no topics and can't be archived
+func (gitProvider *GitGetBitbucket) GetRepositoryMetadata(repoSha, owner, repository string) (metadata.Repository, error) {
+	git := gitProvider.auth(repoSha)
+
+	repo, err := git.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+	})
+	if err != nil {
+		return metadata.Repository{}, err
+	}
+
+	visibility := "public"
+	if repo.Is_private {
+		visibility = "private"
+	}
+
+	return metadata.Repository{
+		Description:   repo.Description,
+		DefaultBranch: repo.Mainbranch.Name,
+		Visibility:    visibility,
+	}, nil
 }
 
-// FetchOwnerRepos - fetch owner repositories via API (method)
-func (gitProvider *GitGetBitbucket) FetchOwnerRepos(repoSha, owner, bitbucketRole string) []bitbucket.Repository {
-	log.Debugf("%s: Specified owner: '%s'", repoSha, owner)
-	var reposToReutrn []bitbucket.Repository
+// UpdateRepositoryMetadata - updates bitbucket repository description and visibility, if they differ
+// from desired metadata, other fields can't be updated via API
+func (gitProvider *GitGetBitbucket) UpdateRepositoryMetadata(
+	repoSha, owner, repository string,
+	current, desired metadata.Repository,
+) error {
+	repoOptions := &bitbucket.RepositoryOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+	}
+	changed := false
+	if desired.Description != "" && current.Description != desired.Description {
+		repoOptions.Description = desired.Description
+		changed = true
+	}
+	// Bitbucket has no internal visibility, such repositories are private
+	if desired.Visibility != "" && (current.Visibility == "public") != (desired.Visibility == "public") {
+		repoOptions.IsPrivate = fmt.Sprintf("%t", desired.Visibility != "public")
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
 
-	git := gitProvider.auth(repoSha)
+	log.Debugf("%s: Updating bitbucket repository '%s/%s' metadata", repoSha, owner, repository)
+	_, err := gitProvider.auth(repoSha).Repositories.Repository.Update(repoOptions)
+
+	return err
+}
+
+// maximum page length allowed by Bitbucket API for repositories listing
+const reposPageLength = 100
 
+func fetchAllRepos(git *bitbucket.Client, repoSha, owner, bitbucketRole string) ([]bitbucket.Repository, error) {
+	var repoList []bitbucket.Repository
+
+	// Pages are requested explicitly to log and control each of them
+	git.Pagelen = reposPageLength
+	page := 1
 	opts := &bitbucket.RepositoriesOptions{
 		Owner: owner,
 		Role:  bitbucketRole,
+		Page:  &page,
 	}
 
-	repos, err := git.Workspaces.Repositories.ListForAccount(opts)
-
-	if repos != nil && len(repos.Items) > 0 && err == nil {
+	for {
+		repos, err := git.Workspaces.Repositories.ListForAccount(opts)
+		if err != nil {
+			return repoList, err
+		}
 		log.Debugf(
-			"%s: Page: %d, Pagelen: %d, Size: %d",
-			repoSha, repos.Page, repos.Pagelen, repos.Size)
-		reposToReutrn = repos.Items
-	} else {
-		log.Errorf("%s: Can't fetch repository list for '%s' '%+v'", repoSha, owner, err)
-	}
+			"%s: Page: %d, Pagelen: %d, Size: %d, Items: %d",
+			repoSha, repos.Page, repos.Pagelen, repos.Size, len(repos.Items))
+
+		repoList = append(repoList, repos.Items...)
+
+		// reached the end of page list
+		if len(repos.Items) == 0 || len(repos.Items) < int(repos.Pagelen) ||
+			(repos.Size > 0 && len(repoList) >= int(repos.Size)) {
+			break
+		}
 
-	for i := 0; repos != nil && i < len(repos.Items) && err == nil; i++ {
-		log.Debugf("%s: Repository '%s(%s)'", repoSha, repos.Items[i].Full_name, repos.Items[i].Mainbranch.Name)
+		// Prepare pagination options for next request
+		page++
 	}
 
-	return reposToReutrn
+	return repoList, nil
 }
 
-// FetchOwnerRepos - fetch owner repositories via API (package function for backward compatibility)
-func FetchOwnerRepos(repoSha, owner, bitbucketRole string) []bitbucket.Repository {
-	gitProvider := &GitGetBitbucket{}
-	gitProvider.Init()
-	return gitProvider.FetchOwnerRepos(repoSha, owner, bitbucketRole)
+// FetchOwnerRepos - fetch owner repositories via API (method)
+func (gitProvider *GitGetBitbucket) FetchOwnerRepos(repoSha, owner, bitbucketRole string) []bitbucket.Repository {
+	log.Debugf("%s: Specified owner: '%s'", repoSha, owner)
+
+	git := gitProvider.auth(repoSha)
+
+	reposToReturn, err := fetchAllRepos(git, repoSha, owner, bitbucketRole)
+	if err != nil {
+		log.Errorf("%s: Can't fetch repository list for '%s' '%+v'", repoSha, owner, err)
+	}
+
+	for i := 0; i < len(reposToReturn); i++ {
+		log.Debugf(
+			"%s: Repository '%s(%s)' project '%s'",
+			repoSha, reposToReturn[i].Full_name, reposToReturn[i].Mainbranch.Name, reposToReturn[i].Project.Key)
+	}
+
+	return reposToReturn
 }
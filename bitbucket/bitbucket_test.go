@@ -4,9 +4,14 @@
 package bitbucket
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"testing"
 
+	bitbucket "github.com/ktrysmt/go-bitbucket"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -124,11 +129,6 @@ func TestGitGetBitbucket_RepositoryExists(t *testing.T) {
 	t.Skip("Requires Bitbucket API mocking or integration test")
 }
 
-func TestRepositoryExists_PackageFunction(t *testing.T) {
-	// This test requires actual Bitbucket API or mocking at HTTP level
-	t.Skip("Requires Bitbucket API mocking or integration test")
-}
-
 func TestProjectExists(t *testing.T) {
 	// This test requires actual Bitbucket API or mocking at HTTP level
 	t.Skip("Requires Bitbucket API mocking or integration test")
@@ -139,17 +139,41 @@ func TestGitGetBitbucket_CreateRepository(t *testing.T) {
 	t.Skip("Requires Bitbucket API mocking or integration test")
 }
 
-func TestCreateRepository_PackageFunction(t *testing.T) {
-	// This test requires actual Bitbucket API or mocking at HTTP level
-	t.Skip("Requires Bitbucket API mocking or integration test")
-}
-
 func TestGitGetBitbucket_FetchOwnerRepos(t *testing.T) {
 	// This test requires actual Bitbucket API or mocking at HTTP level
 	t.Skip("Requires Bitbucket API mocking or integration test")
 }
 
-func TestFetchOwnerRepos_PackageFunction(t *testing.T) {
-	// This test requires actual Bitbucket API or mocking at HTTP level
-	t.Skip("Requires Bitbucket API mocking or integration test")
+func TestFetchAllRepos_Pagination(t *testing.T) {
+	var requestedPages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+		assert.Equal(t, "/repositories/acme", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("pagelen"))
+
+		switch page {
+		case "1":
+			fmt.Fprint(w, `{"page":1,"pagelen":2,"size":3,"values":[{"full_name":"acme/a"},{"full_name":"acme/b"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"page":2,"pagelen":2,"size":3,"values":[{"full_name":"acme/c","project":{"key":"OPS"}}]}`)
+		default:
+			t.Errorf("unexpected page requested: '%s'", page)
+		}
+	}))
+	defer server.Close()
+
+	git, err := bitbucket.NewBasicAuth("user", "token")
+	assert.NoError(t, err)
+	apiURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	git.SetApiBaseURL(*apiURL)
+
+	repos, err := fetchAllRepos(git, "test-sha", "acme", "member")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, requestedPages)
+	assert.Len(t, repos, 3)
+	assert.Equal(t, "acme/c", repos[2].Full_name)
+	assert.Equal(t, "OPS", repos[2].Project.Key)
 }
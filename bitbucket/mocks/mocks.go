@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"github.com/isindir/git-get/metadata"
 	"github.com/ktrysmt/go-bitbucket"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -178,6 +179,78 @@ func (_c *GitGetBitbucketI_FetchOwnerRepos_Call) RunAndReturn(run func(repoSha s
 	return _c
 }
 
+// GetRepositoryMetadata provides a mock function for the type GitGetBitbucketI
+func (_mock *GitGetBitbucketI) GetRepositoryMetadata(repoSha string, owner string, repository string) (metadata.Repository, error) {
+	ret := _mock.Called(repoSha, owner, repository)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepositoryMetadata")
+	}
+
+	var r0 metadata.Repository
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) (metadata.Repository, error)); ok {
+		return returnFunc(repoSha, owner, repository)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) metadata.Repository); ok {
+		r0 = returnFunc(repoSha, owner, repository)
+	} else {
+		r0 = ret.Get(0).(metadata.Repository)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = returnFunc(repoSha, owner, repository)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// GitGetBitbucketI_GetRepositoryMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepositoryMetadata'
+type GitGetBitbucketI_GetRepositoryMetadata_Call struct {
+	*mock.Call
+}
+
+// GetRepositoryMetadata is a helper method to define mock.On call
+//   - repoSha string
+//   - owner string
+//   - repository string
+func (_e *GitGetBitbucketI_Expecter) GetRepositoryMetadata(repoSha interface{}, owner interface{}, repository interface{}) *GitGetBitbucketI_GetRepositoryMetadata_Call {
+	return &GitGetBitbucketI_GetRepositoryMetadata_Call{Call: _e.mock.On("GetRepositoryMetadata", repoSha, owner, repository)}
+}
+
+func (_c *GitGetBitbucketI_GetRepositoryMetadata_Call) Run(run func(repoSha string, owner string, repository string)) *GitGetBitbucketI_GetRepositoryMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetBitbucketI_GetRepositoryMetadata_Call) Return(repository1 metadata.Repository, err error) *GitGetBitbucketI_GetRepositoryMetadata_Call {
+	_c.Call.Return(repository1, err)
+	return _c
+}
+
+func (_c *GitGetBitbucketI_GetRepositoryMetadata_Call) RunAndReturn(run func(repoSha string, owner string, repository string) (metadata.Repository, error)) *GitGetBitbucketI_GetRepositoryMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Init provides a mock function for the type GitGetBitbucketI
 func (_mock *GitGetBitbucketI) Init() bool {
 	ret := _mock.Called()
@@ -222,6 +295,57 @@ func (_c *GitGetBitbucketI_Init_Call) RunAndReturn(run func() bool) *GitGetBitbu
 	return _c
 }
 
+// InitForHost provides a mock function for the type GitGetBitbucketI
+func (_mock *GitGetBitbucketI) InitForHost(host string) bool {
+	ret := _mock.Called(host)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitForHost")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(host)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// GitGetBitbucketI_InitForHost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InitForHost'
+type GitGetBitbucketI_InitForHost_Call struct {
+	*mock.Call
+}
+
+// InitForHost is a helper method to define mock.On call
+//   - host string
+func (_e *GitGetBitbucketI_Expecter) InitForHost(host interface{}) *GitGetBitbucketI_InitForHost_Call {
+	return &GitGetBitbucketI_InitForHost_Call{Call: _e.mock.On("InitForHost", host)}
+}
+
+func (_c *GitGetBitbucketI_InitForHost_Call) Run(run func(host string)) *GitGetBitbucketI_InitForHost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetBitbucketI_InitForHost_Call) Return(b bool) *GitGetBitbucketI_InitForHost_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *GitGetBitbucketI_InitForHost_Call) RunAndReturn(run func(host string) bool) *GitGetBitbucketI_InitForHost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RepositoryExists provides a mock function for the type GitGetBitbucketI
 func (_mock *GitGetBitbucketI) RepositoryExists(repoSha string, owner string, repository string) bool {
 	ret := _mock.Called(repoSha, owner, repository)
@@ -284,3 +408,78 @@ func (_c *GitGetBitbucketI_RepositoryExists_Call) RunAndReturn(run func(repoSha
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateRepositoryMetadata provides a mock function for the type GitGetBitbucketI
+func (_mock *GitGetBitbucketI) UpdateRepositoryMetadata(repoSha string, owner string, repository string, current metadata.Repository, desired metadata.Repository) error {
+	ret := _mock.Called(repoSha, owner, repository, current, desired)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRepositoryMetadata")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, metadata.Repository, metadata.Repository) error); ok {
+		r0 = returnFunc(repoSha, owner, repository, current, desired)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// GitGetBitbucketI_UpdateRepositoryMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRepositoryMetadata'
+type GitGetBitbucketI_UpdateRepositoryMetadata_Call struct {
+	*mock.Call
+}
+
+// UpdateRepositoryMetadata is a helper method to define mock.On call
+//   - repoSha string
+//   - owner string
+//   - repository string
+//   - current metadata.Repository
+//   - desired metadata.Repository
+func (_e *GitGetBitbucketI_Expecter) UpdateRepositoryMetadata(repoSha interface{}, owner interface{}, repository interface{}, current interface{}, desired interface{}) *GitGetBitbucketI_UpdateRepositoryMetadata_Call {
+	return &GitGetBitbucketI_UpdateRepositoryMetadata_Call{Call: _e.mock.On("UpdateRepositoryMetadata", repoSha, owner, repository, current, desired)}
+}
+
+func (_c *GitGetBitbucketI_UpdateRepositoryMetadata_Call) Run(run func(repoSha string, owner string, repository string, current metadata.Repository, desired metadata.Repository)) *GitGetBitbucketI_UpdateRepositoryMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 metadata.Repository
+		if args[3] != nil {
+			arg3 = args[3].(metadata.Repository)
+		}
+		var arg4 metadata.Repository
+		if args[4] != nil {
+			arg4 = args[4].(metadata.Repository)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetBitbucketI_UpdateRepositoryMetadata_Call) Return(err error) *GitGetBitbucketI_UpdateRepositoryMetadata_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *GitGetBitbucketI_UpdateRepositoryMetadata_Call) RunAndReturn(run func(repoSha string, owner string, repository string, current metadata.Repository, desired metadata.Repository) error) *GitGetBitbucketI_UpdateRepositoryMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,350 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package bitbucketserver implements Bitbucket Data Center / Server REST API (/rest/api/1.0) calls.
+package bitbucketserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/isindir/git-get/credentials"
+	"github.com/isindir/git-get/transport"
+)
+
+const (
+	apiPath = "/rest/api/1.0"
+	// maximum page size allowed by default Bitbucket Server configuration
+	pageLimit = 100
+)
+
+// slugRe - characters Bitbucket Server replaces in repository slugs
+var slugRe = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+type GitGetBitbucketServer struct {
+	username string
+	token    string
+	client   *http.Client
+}
+
+// Link - clone link of the repository, Name is either 'ssh' or 'http'
+type Link struct {
+	HREF string `json:"href"`
+	Name string `json:"name"`
+}
+
+// Project - Bitbucket Server project, repositories are always created in a project
+type Project struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// Repository - Bitbucket Server repository
+type Repository struct {
+	Slug        string  `json:"slug"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Public      bool    `json:"public"`
+	Project     Project `json:"project"`
+	Links       struct {
+		Clone []Link `json:"clone"`
+	} `json:"links"`
+	// DefaultBranch is not a part of the repository API response and is fetched separately
+	DefaultBranch string `json:"-"`
+}
+
+type repositoryPage struct {
+	Size          int          `json:"size"`
+	Limit         int          `json:"limit"`
+	Start         int          `json:"start"`
+	IsLastPage    bool         `json:"isLastPage"`
+	NextPageStart int          `json:"nextPageStart"`
+	Values        []Repository `json:"values"`
+}
+
+type projectPage struct {
+	Size          int       `json:"size"`
+	Limit         int       `json:"limit"`
+	Start         int       `json:"start"`
+	IsLastPage    bool      `json:"isLastPage"`
+	NextPageStart int       `json:"nextPageStart"`
+	Values        []Project `json:"values"`
+}
+
+type branch struct {
+	ID        string `json:"id"`
+	DisplayID string `json:"displayId"`
+}
+
+type createRepositoryRequest struct {
+	Name        string `json:"name"`
+	ScmID       string `json:"scmId"`
+	Public      bool   `json:"public"`
+	Description string `json:"description,omitempty"`
+}
+
+type GitGetBitbucketServerI interface {
+	Init() bool
+	InitForHost(host string) bool
+	RepositoryExists(repoSha, baseURL, project, repository string) bool
+	CreateRepository(repoSha, baseURL, project, repository, mirrorVisibilityMode, sourceURL string) *Repository
+	FetchProjects(repoSha, baseURL string) []Project
+	FetchProjectRepos(repoSha, baseURL, project string) []Repository
+	GetDefaultBranch(repoSha, baseURL, project, repository string) string
+}
+
+// Init - reads credentials from environment, see InitForHost
+func (gitProvider *GitGetBitbucketServer) Init() bool {
+	return gitProvider.InitForHost("")
+}
+
+// InitForHost - reads credentials of the host from credentials hosts file or environment,
+// username (BITBUCKET_USERNAME) is optional: if it is not set, token (BITBUCKET_TOKEN) is used
+// as HTTP access token (bearer), otherwise as password
+func (gitProvider *GitGetBitbucketServer) InitForHost(host string) bool {
+	hostCredentials, err := credentials.Lookup(host, "BITBUCKET_USERNAME", "BITBUCKET_TOKEN")
+	if err != nil {
+		log.Fatalf("Error - Bitbucket Server credentials for '%s': %s", host, err)
+		os.Exit(1)
+	}
+	gitProvider.username = hostCredentials.Username
+	gitProvider.token = hostCredentials.Token
+	gitProvider.client = transport.Client()
+
+	return true
+}
+
+// CloneURL - returns clone URL of the repository for requested git schema [ssh|https]
+func (repo *Repository) CloneURL(gitSchema string) string {
+	linkName := gitSchema
+	if gitSchema == "https" {
+		linkName = "http"
+	}
+	for _, link := range repo.Links.Clone {
+		if link.Name == linkName {
+			return link.HREF
+		}
+	}
+
+	return ""
+}
+
+func apiURL(baseURL string, pathElements ...string) string {
+	escaped := make([]string, 0, len(pathElements))
+	for _, element := range pathElements {
+		escaped = append(escaped, url.PathEscape(element))
+	}
+	return strings.TrimSuffix(baseURL, "/") + apiPath + "/" + strings.Join(escaped, "/")
+}
+
+func (gitProvider *GitGetBitbucketServer) request(
+	method, requestURL string,
+	body interface{},
+	result interface{},
+) (int, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, requestURL, reqBody)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if gitProvider.username != "" {
+		req.SetBasicAuth(gitProvider.username, gitProvider.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+gitProvider.token)
+	}
+
+	res, err := gitProvider.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		data, _ := io.ReadAll(res.Body)
+		return res.StatusCode, fmt.Errorf("%s %s: %s: %s", method, requestURL, res.Status, strings.TrimSpace(string(data)))
+	}
+
+	if result != nil {
+		return res.StatusCode, json.NewDecoder(res.Body).Decode(result)
+	}
+
+	return res.StatusCode, nil
+}
+
+// RepositorySlug - returns slug Bitbucket Server derives from repository name: lowercased name with
+// characters other than letters, digits, '.', '_' and '-' replaced by '-' ( My Repo -> my-repo )
+func RepositorySlug(name string) string {
+	return strings.Trim(slugRe.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// RepositoryExists - checks if Bitbucket Server repository exists in the project, repository is
+// looked up by the slug of its name, errors other than not found are fatal
+func (gitProvider *GitGetBitbucketServer) RepositoryExists(repoSha, baseURL, project, repository string) bool {
+	var repo Repository
+	slug := RepositorySlug(repository)
+	statusCode, err := gitProvider.request(http.MethodGet, apiURL(baseURL, "projects", project, "repos", slug), nil, &repo)
+	if statusCode == http.StatusNotFound {
+		log.Debugf("%s: Repository '%s/%s' not found: %+v", repoSha, project, slug, err)
+		return false
+	}
+	if err != nil {
+		log.Fatalf("%s: Error - while trying to fetch bitbucket server repository '%s/%s': '%s'", repoSha, project, slug, err)
+		os.Exit(1)
+	}
+
+	log.Debugf("%s: Fetched repository '%+v'", repoSha, repo)
+	return true
+}
+
+// CreateRepository - create Bitbucket Server repository in the project
+func (gitProvider *GitGetBitbucketServer) CreateRepository(
+	repoSha, baseURL, project, repository, mirrorVisibilityMode, sourceURL string,
+) *Repository {
+	repoDef := &createRepositoryRequest{
+		Name:        repository,
+		ScmID:       "git",
+		Public:      mirrorVisibilityMode == "public",
+		Description: fmt.Sprintf("Mirror of the '%s'", sourceURL),
+	}
+	log.Debugf("%s: Creating repository in project '%s' with parameters: '%+v'", repoSha, project, repoDef)
+
+	var resultingRepository Repository
+	_, err := gitProvider.request(
+		http.MethodPost, apiURL(baseURL, "projects", project, "repos"), repoDef, &resultingRepository)
+	if err != nil {
+		log.Fatalf(
+			"%s: Error - while trying to create bitbucket server repository '%s/%s': '%s'",
+			repoSha, project, repository, err)
+		os.Exit(1)
+	}
+
+	log.Debugf("%s: Repository created: '%+v'", repoSha, resultingRepository)
+	return &resultingRepository
+}
+
+// GetDefaultBranch - returns default branch name of the repository or empty string if it can't be found
+func (gitProvider *GitGetBitbucketServer) GetDefaultBranch(repoSha, baseURL, project, repository string) string {
+	var defaultBranch branch
+	status, err := gitProvider.request(
+		http.MethodGet, apiURL(baseURL, "projects", project, "repos", repository, "default-branch"), nil, &defaultBranch)
+	if status == http.StatusNotFound {
+		// older Bitbucket Server versions
+		_, err = gitProvider.request(
+			http.MethodGet, apiURL(baseURL, "projects", project, "repos", repository, "branches", "default"), nil, &defaultBranch)
+	}
+	if err != nil {
+		log.Debugf("%s: Error fetching default branch of '%s/%s': %+v", repoSha, project, repository, err)
+		return ""
+	}
+
+	return defaultBranch.DisplayID
+}
+
+// FetchProjects - fetch all projects visible to the user via API, errors are fatal as repositories
+// of unlisted projects would be silently missing
+func (gitProvider *GitGetBitbucketServer) FetchProjects(repoSha, baseURL string) []Project {
+	var projectList []Project
+
+	start := 0
+	for {
+		var page projectPage
+		requestURL := fmt.Sprintf("%s?start=%d&limit=%d", apiURL(baseURL, "projects"), start, pageLimit)
+		_, err := gitProvider.request(http.MethodGet, requestURL, nil, &page)
+		if err != nil {
+			log.Fatalf("%s: Error - while trying to fetch bitbucket server project list: '%s'", repoSha, err)
+			os.Exit(1)
+		}
+		log.Debugf(
+			"%s: Start: %d, Limit: %d, Size: %d, IsLastPage: %t",
+			repoSha, page.Start, page.Limit, page.Size, page.IsLastPage)
+
+		projectList = append(projectList, page.Values...)
+
+		// reached the end of page list
+		if page.IsLastPage || len(page.Values) == 0 {
+			break
+		}
+
+		// Prepare pagination options for next request
+		start = page.NextPageStart
+	}
+
+	return projectList
+}
+
+// FetchProjectRepos - fetch all repositories of the project via API
+func (gitProvider *GitGetBitbucketServer) FetchProjectRepos(repoSha, baseURL, project string) []Repository {
+	log.Debugf("%s: Specified project: '%s'", repoSha, project)
+	var repoList []Repository
+
+	start := 0
+	for {
+		var page repositoryPage
+		requestURL := fmt.Sprintf("%s?start=%d&limit=%d", apiURL(baseURL, "projects", project, "repos"), start, pageLimit)
+		_, err := gitProvider.request(http.MethodGet, requestURL, nil, &page)
+		if err != nil {
+			log.Errorf("%s: Can't fetch repository list for project '%s' '%+v'", repoSha, project, err)
+			break
+		}
+		log.Debugf(
+			"%s: Start: %d, Limit: %d, Size: %d, IsLastPage: %t",
+			repoSha, page.Start, page.Limit, page.Size, page.IsLastPage)
+
+		repoList = append(repoList, page.Values...)
+
+		// reached the end of page list
+		if page.IsLastPage || len(page.Values) == 0 {
+			break
+		}
+
+		// Prepare pagination options for next request
+		start = page.NextPageStart
+	}
+
+	for i := 0; i < len(repoList); i++ {
+		repoList[i].DefaultBranch = gitProvider.GetDefaultBranch(repoSha, baseURL, project, repoList[i].Slug)
+		log.Debugf("%s: Repository '%s/%s(%s)'", repoSha, project, repoList[i].Slug, repoList[i].DefaultBranch)
+	}
+
+	return repoList
+}
@@ -0,0 +1,159 @@
+//go:build !integration
+// +build !integration
+
+package bitbucketserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestProvider(t *testing.T, handler http.Handler) (*GitGetBitbucketServer, string) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	t.Setenv("BITBUCKET_USERNAME", "")
+	t.Setenv("BITBUCKET_TOKEN", "test-token-123")
+	gitProvider := &GitGetBitbucketServer{}
+	gitProvider.Init()
+
+	return gitProvider, server.URL
+}
+
+func TestGitGetBitbucketServer_Init_Success(t *testing.T) {
+	t.Setenv("BITBUCKET_USERNAME", "test-user")
+	t.Setenv("BITBUCKET_TOKEN", "test-token-123")
+
+	gitProvider := &GitGetBitbucketServer{}
+	result := gitProvider.Init()
+
+	assert.True(t, result)
+	assert.Equal(t, "test-user", gitProvider.username)
+	assert.Equal(t, "test-token-123", gitProvider.token)
+}
+
+func TestRepository_CloneURL(t *testing.T) {
+	repo := Repository{}
+	repo.Links.Clone = []Link{
+		{HREF: "ssh://git@bitbucket.acme.com:7999/ops/infra.git", Name: "ssh"},
+		{HREF: "https://bitbucket.acme.com/scm/ops/infra.git", Name: "http"},
+	}
+
+	assert.Equal(t, "ssh://git@bitbucket.acme.com:7999/ops/infra.git", repo.CloneURL("ssh"))
+	assert.Equal(t, "https://bitbucket.acme.com/scm/ops/infra.git", repo.CloneURL("https"))
+	assert.Equal(t, "", (&Repository{}).CloneURL("ssh"))
+}
+
+func TestGitGetBitbucketServer_FetchProjectRepos(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/OPS/repos", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token-123", r.Header.Get("Authorization"))
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		switch r.URL.Query().Get("start") {
+		case "0":
+			fmt.Fprint(w, `{"size":1,"limit":1,"start":0,"isLastPage":false,"nextPageStart":1,`+
+				`"values":[{"slug":"infra","project":{"key":"OPS"}}]}`)
+		case "1":
+			fmt.Fprint(w, `{"size":1,"limit":1,"start":1,"isLastPage":true,`+
+				`"values":[{"slug":"alerts","project":{"key":"OPS"}}]}`)
+		default:
+			t.Errorf("unexpected start requested: '%s'", r.URL.Query().Get("start"))
+		}
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/OPS/repos/infra/default-branch", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"refs/heads/main","displayId":"main"}`)
+	})
+	// older Bitbucket Server versions have no default-branch endpoint
+	mux.HandleFunc("/rest/api/1.0/projects/OPS/repos/alerts/default-branch", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/OPS/repos/alerts/branches/default", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"refs/heads/master","displayId":"master"}`)
+	})
+
+	gitProvider, baseURL := newTestProvider(t, mux)
+	repos := gitProvider.FetchProjectRepos("test-sha", baseURL, "OPS")
+
+	assert.Len(t, repos, 2)
+	assert.Equal(t, "infra", repos[0].Slug)
+	assert.Equal(t, "main", repos[0].DefaultBranch)
+	assert.Equal(t, "alerts", repos[1].Slug)
+	assert.Equal(t, "master", repos[1].DefaultBranch)
+}
+
+func TestGitGetBitbucketServer_FetchProjects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		switch r.URL.Query().Get("start") {
+		case "0":
+			fmt.Fprint(w, `{"size":2,"limit":2,"start":0,"isLastPage":false,"nextPageStart":2,`+
+				`"values":[{"key":"OPS"},{"key":"DEV"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"size":1,"limit":2,"start":2,"isLastPage":true,"values":[{"key":"QA"}]}`)
+		default:
+			t.Errorf("unexpected start requested: '%s'", r.URL.Query().Get("start"))
+		}
+	})
+
+	gitProvider, baseURL := newTestProvider(t, mux)
+	projects := gitProvider.FetchProjects("test-sha", baseURL)
+
+	assert.Equal(t, []Project{{Key: "OPS"}, {Key: "DEV"}, {Key: "QA"}}, projects)
+}
+
+func TestGitGetBitbucketServer_RepositoryExists(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/OPS/repos/infra", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"slug":"infra","project":{"key":"OPS"}}`)
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/OPS/repos/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	mux.HandleFunc("/rest/api/1.0/projects/OPS/repos/my-repo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"slug":"my-repo","name":"My Repo","project":{"key":"OPS"}}`)
+	})
+
+	gitProvider, baseURL := newTestProvider(t, mux)
+
+	assert.True(t, gitProvider.RepositoryExists("test-sha", baseURL, "OPS", "infra"))
+	assert.True(t, gitProvider.RepositoryExists("test-sha", baseURL, "OPS", "My Repo"))
+	assert.False(t, gitProvider.RepositoryExists("test-sha", baseURL, "OPS", "missing"))
+}
+
+func TestRepositorySlug(t *testing.T) {
+	assert.Equal(t, "infra", RepositorySlug("infra"))
+	assert.Equal(t, "my-repo", RepositorySlug("My Repo"))
+	assert.Equal(t, "api_v2.core", RepositorySlug("API_v2.core"))
+	assert.Equal(t, "a-b", RepositorySlug(" a / b "))
+}
+
+func TestGitGetBitbucketServer_CreateRepository(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/MIRRORS/repos", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		var req createRepositoryRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, createRepositoryRequest{
+			Name:        "infra",
+			ScmID:       "git",
+			Public:      false,
+			Description: "Mirror of the 'git@github.com:acme/infra.git'",
+		}, req)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"slug":"infra","project":{"key":"MIRRORS"}}`)
+	})
+
+	gitProvider, baseURL := newTestProvider(t, mux)
+	repo := gitProvider.CreateRepository(
+		"test-sha", baseURL, "MIRRORS", "infra", "private", "git@github.com:acme/infra.git")
+
+	assert.Equal(t, "infra", repo.Slug)
+	assert.Equal(t, "MIRRORS", repo.Project.Key)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package cmd provides logic for cli entrance point.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/isindir/git-get/credentials"
+	"github.com/isindir/git-get/gitget"
+	"github.com/spf13/cobra"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// bundleCmd represents the bundle command
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Create or restore offline backups of repositories specified by Gitfile",
+	Long: `
+Create 'git bundle' files of repositories specified by configuration file, or restore clones
+from them without network access, e.g. for offline backups or fast onboarding.
+
+Bundles are written to '<bundle dir>/<path>/<altname>.bundle' following Gitfile paths.`,
+}
+
+// bundleCreateCmd represents the bundle create command
+var bundleCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create bundles with all refs of repositories specified by Gitfile",
+	Long: `
+Create 'git bundle' with all refs of every repository specified by configuration file.
+
+Every repository is cloned with 'git clone --mirror' to a temporary directory and bundled to
+'<bundle dir>/<path>/<altname>.bundle'. Manifest '<bundle dir>/manifest.yaml' lists URL, path,
+altname, ref, commit SHA of the ref and bundle file of every bundled repository.
+
+Notes:
+
+* Command exits with non zero status if any repository failed to bundle.`,
+	Example: `
+git-get bundle create -f Gitfile --bundle-dir backups
+git-get bundle create -c 8 -f Gitfile -i Gitfile.ignore --bundle-dir backups --https-token-auth`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkConfigFiles()
+		initLogging()
+		credentials.SetHostsFile(credentialsFile)
+		created := gitget.CreateBundles(
+			cfgFiles,
+			ignoreFiles,
+			concurrencyLevel,
+			defaultMainBranch,
+			httpsTokenAuth,
+			bundleDir,
+		)
+		if !created {
+			os.Exit(1)
+		}
+	},
+}
+
+// bundleRestoreCmd represents the bundle restore command
+var bundleRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore clones of repositories specified by Gitfile from bundles",
+	Long: `
+Restore clones of repositories specified by configuration file from bundles created by
+'git-get bundle create', no network access is needed.
+
+Every repository is cloned from '<bundle dir>/<path>/<altname>.bundle' at its Gitfile path
+and ref, then 'origin' remote is set to repository URL, so that clone can be refreshed with
+'git-get' later.
+
+Notes:
+
+* Existing repository clones are not changed.
+* Command exits with non zero status if any repository failed to restore.`,
+	Example: `
+git-get bundle restore -f Gitfile --bundle-dir backups
+git-get bundle restore -c 8 -f Gitfile -i Gitfile.ignore --bundle-dir /mnt/backups`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkConfigFiles()
+		initLogging()
+		restored := gitget.RestoreBundles(
+			cfgFiles,
+			ignoreFiles,
+			concurrencyLevel,
+			defaultMainBranch,
+			bundleDir,
+		)
+		if !restored {
+			os.Exit(1)
+		}
+	},
+}
+
+// checkConfigFiles - exits if any configuration file does not exist
+func checkConfigFiles() {
+	for _, cfgFile := range cfgFiles {
+		if _, err := os.Stat(cfgFile); os.IsNotExist(err) {
+			log.Fatalln(err)
+			os.Exit(1)
+		}
+	}
+}
+
+// addBundleFlags - adds flags shared by bundle subcommands
+func addBundleFlags(command *cobra.Command, defaultValue, defaultIgnoreValue string) {
+	command.Flags().StringSliceVarP(
+		&cfgFiles, "config-file",
+		"f",
+		[]string{defaultValue},
+		"Configuration file or comma separated list of files")
+	command.Flags().StringSliceVarP(
+		&ignoreFiles, "ignore-file",
+		"i",
+		[]string{defaultIgnoreValue},
+		"Ignore file or comma separated list of files")
+	command.Flags().StringVarP(
+		&logLevel, "log-level",
+		"l",
+		"info",
+		"Logging level [debug|info|warn|error|fatal|panic]")
+	command.Flags().IntVarP(
+		&concurrencyLevel, "concurrency-level",
+		"c",
+		1,
+		"Git get concurrency level")
+	command.Flags().StringVarP(
+		&defaultMainBranch, "default-main-branch",
+		"b",
+		"master",
+		"Default main branch")
+	command.Flags().StringVar(
+		&bundleDir, "bundle-dir",
+		"bundles",
+		"Directory with repository bundles and manifest")
+}
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+	bundleCmd.AddCommand(bundleCreateCmd)
+	bundleCmd.AddCommand(bundleRestoreCmd)
+
+	wdir, err := os.Getwd()
+	if err != nil {
+		log.Fatalln(err)
+		os.Exit(1)
+	}
+
+	defaultValue := filepath.Join(wdir, "Gitfile")
+	defaultIgnoreValue := fmt.Sprintf("%s.ignore", defaultValue)
+	addBundleFlags(bundleCreateCmd, defaultValue, defaultIgnoreValue)
+	addBundleFlags(bundleRestoreCmd, defaultValue, defaultIgnoreValue)
+	bundleCreateCmd.Flags().BoolVar(
+		&httpsTokenAuth, "https-token-auth",
+		false,
+		"Pass provider API token to git for https repository URLs (see --credentials-file)")
+	bundleCreateCmd.Flags().StringVar(
+		&credentialsFile, "credentials-file",
+		"",
+		"Credentials hosts file with per host credential sources (default ~/.config/git-get/hosts.yaml)")
+}
@@ -27,6 +27,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/isindir/git-get/credentials"
 	"github.com/isindir/git-get/gitget"
 	"github.com/spf13/cobra"
 
@@ -41,21 +42,36 @@ var configGenCmd = &cobra.Command{
 Create 'Gitfile' configuration file dynamically from git provider by specifying
 top level URL of the organisation organization, user or for Gitlab provider Group name.
 
-* Github: Environment variable GITHUB_TOKEN defined.
+* Github: Environment variable GITHUB_TOKEN (classic or fine-grained token) defined.
+* Github App: instead of GITHUB_TOKEN environment variables GITHUB_APP_ID, GITHUB_APP_INSTALLATION_ID
+  and GITHUB_APP_PRIVATE_KEY_FILE defined, installation tokens are requested and refreshed automatically.
+* Github Enterprise Server: selected by config URL host other than github.com, API is accessed
+  via https://<host>/api/v3, '--github-ca-bundle' adds custom CA certificates for TLS verification.
 * Bitbucket: Environment variables BITBUCKET_USERNAME and BITBUCKET_TOKEN (password) defined.
+* Bitbucket Data Center / Server: selected by '--bitbucket-server-url', config URL specifies project
+  key, URL without project fetches repositories of all projects, BITBUCKET_USERNAME is optional, if it is not defined, BITBUCKET_TOKEN is used as HTTP access token.
 * Gitlab: Environment variable GITLAB_TOKEN defined.
 * Gitlab: provider allows to create hierarchy of groups, 'git-get' is capable of fetching
-  this hierarchy to 'Gifile' from any level visible to the user (see examples).`,
+  this hierarchy to 'Gifile' from any level visible to the user (see examples).
+* Credentials: per host credential sources (env, file, git-credential, command) can be configured
+  in '--credentials-file' (default ~/.config/git-get/hosts.yaml), see README.md for the file format.`,
 	Example: `
 git-get config-gen -f Gitfile -p "gitlab" -u "git@gitlab.com:johndoe" -t misc -l debug
 git-get config-gen -f Gitfile -p "gitlab" -u "git@gitlab.com:AcmeOrg" -t misc -l debug
 git-get config-gen -f Gitfile -p "gitlab" -u "git@gitlab.com:AcmeOrg/kube" -g "https"
 git-get config-gen -f Gitfile -p "bitbucket" -u "git@bitbucket.com:AcmeOrg" -t AcmeOrg
+git-get config-gen -f Gitfile -p "bitbucket" -u "git@bitbucket.com:AcmeOrg" --bitbucket-project KUBE,OPS --bitbucket-path-by-project
+git-get config-gen -f Gitfile -p "bitbucket" -u "ssh://git@bitbucket.acme.com:7999/KUBE" --bitbucket-server-url "https://bitbucket.acme.com"
+git-get config-gen -f Gitfile -p "bitbucket" -u "ssh://git@bitbucket.acme.com:7999" --bitbucket-server-url "https://bitbucket.acme.com" --bitbucket-path-by-project
 git-get config-gen -f Gitfile -p "github" -u "git@github.com:johndoe" -t johndoe -l debug
-git-get config-gen -f Gitfile -p "github" -u "git@github.com:AcmeOrg" -t AcmeOrg -l debug`,
+git-get config-gen -f Gitfile -p "github" -u "git@github.com:AcmeOrg" -t AcmeOrg -l debug
+git-get config-gen -f Gitfile -p "github" -u "git@github.com:johndoe" -t src --github-path-by-owner
+git-get config-gen -f Gitfile -p "github" -u "git@github.com:AcmeOrg" -t AcmeOrg --github-team platform
+git-get config-gen -f Gitfile -p "github" -u "git@ghe.acme.com:AcmeOrg" -t AcmeOrg --github-ca-bundle /etc/ssl/acme-ca.pem`,
 	Run: func(cmd *cobra.Command, args []string) {
 		initLogging()
 		log.Debug("Generate Gitfile configuration file")
+		credentials.SetHostsFile(credentialsFile)
 		gitget.GenerateGitfileConfig(
 			cfgFile,
 			ignoreFiles,
@@ -94,6 +110,11 @@ func init() {
 		"l",
 		"info",
 		"Logging level [debug|info|warn|error|fatal|panic]")
+	configGenCmd.Flags().StringVar(
+		&credentialsFile,
+		"credentials-file",
+		"",
+		"Credentials hosts file with per host credential sources (default ~/.config/git-get/hosts.yaml)")
 	configGenCmd.Flags().StringVarP(
 		&configGenParams.GitSchema,
 		"generate-url-of-type",
@@ -144,17 +165,40 @@ func init() {
 		"owner,collaborator,organization_member",
 		`Github: affiliation - comma-separated list of values.
 Can include: owner, collaborator, or organization_member`)
-	/*
-		MAYBE: implement for bitbucket to allow subset of repositories
-		configGenCmd.Flags().StringVar(
-			&configGenParams.BitbucketDivision,
-			"bitbucket-division",
-			"account",
-			"Bitbucket: Get repositories for [account|team]")
-	*/
+	configGenCmd.Flags().BoolVar(
+		&configGenParams.GithubPathByOwner,
+		"github-path-by-owner",
+		false,
+		"Github: set 'path' for each repository to include repository owner (owner/repo)")
+	configGenCmd.Flags().StringVar(
+		&configGenParams.GithubTeam,
+		"github-team",
+		"",
+		`Github: only fetch repositories of the organization team (team slug) and its nested teams,
+'path' of each repository contains team hierarchy (team/nested-team/repo)`)
+	configGenCmd.Flags().StringVar(
+		&configGenParams.GithubCABundle,
+		"github-ca-bundle",
+		"",
+		"Github: PEM encoded CA certificates bundle file used to verify Github Enterprise Server certificate")
 	configGenCmd.Flags().StringVar(
 		&configGenParams.BitbucketRole,
 		"bitbucket-role",
 		"member",
 		"Bitbucket: Filter repositories by role [owner|admin|contributor|member]")
+	configGenCmd.Flags().StringSliceVar(
+		&configGenParams.BitbucketProjects,
+		"bitbucket-project",
+		[]string{},
+		"Bitbucket: only fetch repositories from project key or comma separated list of project keys")
+	configGenCmd.Flags().BoolVar(
+		&configGenParams.BitbucketPathByProject,
+		"bitbucket-path-by-project",
+		false,
+		"Bitbucket: set 'path' for each repository to include project key (PROJECT/repo)")
+	configGenCmd.Flags().StringVar(
+		&configGenParams.BitbucketServerURL,
+		"bitbucket-server-url",
+		"",
+		"Bitbucket Data Center / Server base URL, when set Server REST API is used instead of Bitbucket Cloud (example: https://bitbucket.acme.com)")
 }
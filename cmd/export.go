@@ -0,0 +1,139 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package cmd provides logic for cli entrance point.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/isindir/git-get/credentials"
+	"github.com/isindir/git-get/gitget"
+	"github.com/spf13/cobra"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export repositories specified by Gitfile to archives or vendored directory tree",
+	Long: `
+Export repositories specified by configuration file at their refs to archives or to a vendored
+directory tree, e.g. to use them in air-gapped builds.
+
+Only the ref commit of every repository is fetched to a temporary bare clone, its files are
+written with 'git archive' to '<output dir>/<path>/<altname>.<format>' or checked out to
+'<output dir>/<path>/<altname>' with '--format tree'. Manifest '<output dir>/manifest.yaml'
+lists URL, path, altname, ref, exported commit SHA and file of every exported repository.
+
+Notes:
+
+* Gitfile 'sparse' paths limit exported files, 'filter' is used for the temporary clone.
+* Submodules are not exported, export them as separate Gitfile repositories if needed.
+* '--format tree' fails before export if target directory of a repository is the same as or nested in
+  target of another repository, as existing targets are replaced.
+* Command exits with non zero status if any repository failed to export.`,
+	Example: `
+git-get export -f Gitfile -o vendor-archives
+git-get export -c 8 -f Gitfile -o vendor --format tree
+git-get export -f Gitfile -i Gitfile.ignore -o dist --format zip --https-token-auth`,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, cfgFile := range cfgFiles {
+			if _, err := os.Stat(cfgFile); os.IsNotExist(err) {
+				log.Fatalln(err)
+				os.Exit(1)
+			}
+		}
+		initLogging()
+		credentials.SetHostsFile(credentialsFile)
+		exported := gitget.ExportRepositories(
+			cfgFiles,
+			ignoreFiles,
+			concurrencyLevel,
+			defaultMainBranch,
+			httpsTokenAuth,
+			exportOutputDir,
+			exportFormat,
+		)
+		if !exported {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	wdir, err := os.Getwd()
+	if err != nil {
+		log.Fatalln(err)
+		os.Exit(1)
+	}
+
+	defaultValue := filepath.Join(wdir, "Gitfile")
+	defaultIgnoreValue := fmt.Sprintf("%s.ignore", defaultValue)
+	exportCmd.Flags().StringSliceVarP(
+		&cfgFiles, "config-file",
+		"f",
+		[]string{defaultValue},
+		"Configuration file or comma separated list of files")
+	exportCmd.Flags().StringSliceVarP(
+		&ignoreFiles, "ignore-file",
+		"i",
+		[]string{defaultIgnoreValue},
+		"Ignore file or comma separated list of files")
+	exportCmd.Flags().StringVarP(
+		&logLevel, "log-level",
+		"l",
+		"info",
+		"Logging level [debug|info|warn|error|fatal|panic]")
+	exportCmd.Flags().IntVarP(
+		&concurrencyLevel, "concurrency-level",
+		"c",
+		1,
+		"Git get concurrency level")
+	exportCmd.Flags().StringVarP(
+		&defaultMainBranch, "default-main-branch",
+		"b",
+		"master",
+		"Default main branch")
+	exportCmd.Flags().StringVarP(
+		&exportOutputDir, "output-dir",
+		"o",
+		"export",
+		"Directory to write exported repositories and manifest to")
+	exportCmd.Flags().StringVar(
+		&exportFormat, "format",
+		gitget.ExportFormatTarGz,
+		"Export format [tar|tar.gz|zip|tree]")
+	exportCmd.Flags().BoolVar(
+		&httpsTokenAuth, "https-token-auth",
+		false,
+		"Pass provider API token to git for https repository URLs (see --credentials-file)")
+	exportCmd.Flags().StringVar(
+		&credentialsFile, "credentials-file",
+		"",
+		"Credentials hosts file with per host credential sources (default ~/.config/git-get/hosts.yaml)")
+}
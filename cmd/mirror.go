@@ -28,6 +28,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/isindir/git-get/credentials"
 	"github.com/isindir/git-get/gitget"
 	"github.com/spf13/cobra"
 
@@ -48,16 +49,85 @@ Notes:
 
 * All providers: ssh key is used to clone/push git repositories, where environment
   variables are used to interrogate API.
+* All providers: with '--https-token-auth' API token is also used to clone/push repositories
+  specified by https URLs, token is passed to git via environment and is not stored in git config.
 * Gitlab: ssh key configured and environment variable GITLAB_TOKEN defined.
-* Github: ssh key configured and environment variable GITHUB_TOKEN defined.
+* Github: ssh key configured and environment variable GITHUB_TOKEN (classic or fine-grained token) defined.
+* Github App: instead of GITHUB_TOKEN environment variables GITHUB_APP_ID, GITHUB_APP_INSTALLATION_ID
+  and GITHUB_APP_PRIVATE_KEY_FILE defined, installation tokens are requested and refreshed automatically.
+* Github: mirror repositories are created in organization specified by mirror URL, or for the token
+  owner if mirror URL specifies user, '--github-mirror-team' grants organization team
+  '--github-mirror-team-permission' on created mirror repositories and existing ones the team has no
+  access to, mirror URL must specify organization the team belongs to.
+* Github Enterprise Server: selected by mirror URL host other than github.com, API is accessed
+  via https://<host>/api/v3, '--github-ca-bundle' adds custom CA certificates for TLS verification.
 * Bitbucket: ssh key configured and environment variables BITBUCKET_USERNAME and BITBUCKET_TOKEN (password) defined.
 * Bitbucket: Application won't create Project in Bitbucket if project is specified but missing.
   It assumes the Key of project to be constructed from it's name as Uppercase text containing
-  only [A-Z0-9_] characters, all the rest of the characters from Project Name will be removed.`,
+  only [A-Z0-9_] characters, all the rest of the characters from Project Name will be removed.
+* Bitbucket Data Center / Server: selected by '--bitbucket-server-url', mirror URL specifies
+  project key (example: ssh://git@bitbucket.acme.com:7999/MIRRORS), BITBUCKET_USERNAME is optional,
+  if it is not defined, BITBUCKET_TOKEN is used as HTTP access token.
+* Gitlab: missing groups and subgroups of the mirror URL namespace are created with mirror visibility mode.
+* Gitlab: mirror project and group paths are slugs of mirror names ('My Repo' -> 'my-repo'), project
+  keeps the original name. Projects are created in existing group or user namespace of the mirror URL,
+  creating projects in namespace of another user requires administrator token. '--gitlab-namespace-id'
+  identifies mirror namespace by ID, mirror URL path is replaced by its current full path.
+* Mirror naming: '--mirror-naming' defines mirror repository name below mirror URL, for source
+  git@gitlab.com:src/a/b/repo.git:
+  * flat - repo (default)
+  * namespace - a/b/repo (Gitlab only)
+  * full-path - src/a/b/repo (Gitlab only)
+  * full-path-dash - src-a-b-repo
+  * template - rendered '--mirror-naming-template' Go template with fields .Host, .Owner, .Namespace,
+    .Name, .AltName and .FullPath (example: '{{.Owner}}_{{.AltName}}'), names containing '/' are Gitlab only.
+  Mirror names of all repositories are checked before any push, run fails if several source
+  repositories would be mirrored to the same repository.
+* Mirror cache: with '--mirror-cache-dir' bare repositories are kept between runs in
+  <cache dir>/<source host>/<source path>.git, updated with 'git remote update --prune' and pushed only
+  when refs changed since the last successful push, '--mirror-cache-fsck' checks integrity of cached
+  repositories and clones corrupted ones again.
+* Mirror refs: by default all refs are pushed with 'git push --mirror', '--mirror-refs' limits pushed refs
+  to patterns (example: refs/heads/*,refs/tags/*), '--mirror-exclude-refs' excludes refs patterns
+  (example: refs/pull/*,refs/merge-requests/*), '--mirror-no-delete' keeps mirror refs deleted in source.
+  Gitfile repository entries can override refs patterns and extend exclude patterns with 'mirror_refs',
+  'mirror_exclude_refs' and 'mirror_no_delete' fields. Refs rejected by mirror are reported individually,
+  rejected hidden refs (refs/pull/*, refs/merge-requests/*) don't fail push of the other refs, but the
+  cached repository is pushed again on the next run, exclude them to avoid it.
+* Native mode: with '--mode native' repositories are not cloned and pushed, instead mirror provider is
+  configured to pull source repositories on its side over https (Gitlab pull mirroring), so git-get only
+  needs to run when repositories are added. Source repositories must use https URLs in this mode. With '--https-token-auth' source host token is passed to
+  the mirror provider to pull private repositories.
+* Metadata: with '--sync-metadata' description, homepage, topics and archived state of source repositories
+  are copied to mirrors on create and on every run, mirror default branch is set to configured 'ref'
+  (or source default branch) and visibility to '--mirror-visibility-mode'. Source hosts are matched to
+  providers by name (github, gitlab, bitbucket.org), their API credentials are required. Unsupported
+  fields are skipped: Gitlab has no homepage, Bitbucket only supports description and visibility,
+  Bitbucket Server is not supported.
+* Git LFS: LFS objects of repositories with 'filter=lfs' in default branch '.gitattributes' or 'lfs: true'
+  in Gitfile are fetched with 'git lfs fetch --all' and pushed with 'git lfs push --all' before refs,
+  git-lfs must be installed. LFS failures don't stop refs mirroring and are reported at the end of the run.
+* Summary: '--status' prints table of mirrored repositories with created mirrors, number of pushed (new),
+  updated, deleted and rejected refs, size of pushed objects, duration and errors, '--report-file' writes
+  the same summary to JSON file.
+* Verification: 'git-get mirror verify' compares refs of source repositories and mirrors with
+  'git ls-remote' without cloning and reports missing, extra and divergent refs.
+* Credentials: per host credential sources (env, file, git-credential, command) can be configured
+  in '--credentials-file' (default ~/.config/git-get/hosts.yaml), see README.md for the file format.`,
 	Example: `
 git get mirror -f Gitfile -u "git@github.com:acmeorg" -p "github"
+git get mirror -f Gitfile -u "git@ghe.acme.com:acmeorg" -p "github" --github-ca-bundle /etc/ssl/acme-ca.pem
+git get mirror -f Gitfile -u "git@github.com:acmeorg" -p "github" --github-mirror-team platform --github-mirror-team-permission maintain
 git-get mirror -c 2 -f Gitfile -l debug -u "git@gitlab.com:acmeorg/mirrors"
-git-get mirror -c 2 -f Gitfile -l debug -u "git@bitbucket.com:acmeorg" -p "bitbucket" -b "mirrors"`,
+git-get mirror -f Gitfile -u "git@gitlab.com:acmeorg/mirrors" --mirror-naming namespace
+git-get mirror -f Gitfile -u "git@gitlab.com:acmeorg/mirrors" --mirror-cache-dir ~/.cache/git-get/mirrors
+git-get mirror -f Gitfile -u "git@gitlab.acme.com:acmeorg/mirrors" --mode native --https-token-auth
+git-get mirror -f Gitfile -u "git@github.com:acmeorg" -p "github" --sync-metadata
+git-get mirror -f Gitfile -u "git@github.com:acmeorg" -p "github" --status --report-file mirror-report.json
+git-get mirror -f Gitfile -u "git@github.com:acmeorg" -p "github" --mirror-refs "refs/heads/*,refs/tags/*" --mirror-exclude-refs "refs/heads/tmp/*"
+git-get mirror -f Gitfile -u "git@github.com:acmeorg" -p "github" --mirror-naming template --mirror-naming-template "{{.Owner}}_{{.AltName}}"
+git-get mirror -c 2 -f Gitfile -l debug -u "git@bitbucket.com:acmeorg" -p "bitbucket" -b "mirrors"
+git-get mirror -f Gitfile -p "bitbucket" -u "ssh://git@bitbucket.acme.com:7999/MIRRORS" --bitbucket-server-url "https://bitbucket.acme.com"`,
 	Run: func(cmd *cobra.Command, args []string) {
 		for _, cfgFile := range cfgFiles {
 			if _, err := os.Stat(cfgFile); os.IsNotExist(err) {
@@ -67,6 +137,7 @@ git-get mirror -c 2 -f Gitfile -l debug -u "git@bitbucket.com:acmeorg" -p "bitbu
 		}
 		initLogging()
 		log.Debugf("%t - push to mirror", pushMirror)
+		credentials.SetHostsFile(credentialsFile)
 		pushMirror = !dryRun
 		gitget.MirrorRepositories(
 			cfgFiles,
@@ -77,6 +148,23 @@ git-get mirror -c 2 -f Gitfile -l debug -u "git@bitbucket.com:acmeorg" -p "bitbu
 			gitCloudProvider,
 			mirrorVisibilityMode,
 			mirrorBitbucketProjectName,
+			mirrorBitbucketServerURL,
+			mirrorGithubCABundle,
+			httpsTokenAuth,
+			mirrorNaming,
+			mirrorNamingTemplate,
+			mirrorCacheDir,
+			mirrorCacheFsck,
+			mirrorRefs,
+			mirrorExcludeRefs,
+			mirrorNoDelete,
+			mirrorMode,
+			mirrorSyncMetadata,
+			mirrorGithubTeam,
+			mirrorGithubTeamPermission,
+			mirrorGitlabNamespaceID,
+			status,
+			mirrorReportFile,
 		)
 	},
 }
@@ -108,6 +196,26 @@ func init() {
 		"info",
 		"Logging level [debug|info|warn|error|fatal|panic]",
 	)
+	mirrorCmd.Flags().StringVar(
+		&credentialsFile, "credentials-file",
+		"",
+		"Credentials hosts file with per host credential sources (default ~/.config/git-get/hosts.yaml)",
+	)
+	mirrorCmd.Flags().BoolVar(
+		&httpsTokenAuth, "https-token-auth",
+		false,
+		"Pass provider API token to git for https source and mirror repository URLs",
+	)
+	mirrorCmd.Flags().BoolVar(
+		&status, "status",
+		false,
+		"Print mirror summary table after mirror is performed",
+	)
+	mirrorCmd.Flags().StringVar(
+		&mirrorReportFile, "report-file",
+		"",
+		"Write mirror summary of every repository to JSON file",
+	)
 	mirrorCmd.Flags().IntVarP(
 		&concurrencyLevel, "concurrency-level",
 		"c",
@@ -138,10 +246,80 @@ func init() {
 		"private",
 		"Mirror visibility mode [private|internal|public]",
 	)
+	mirrorCmd.Flags().Int64Var(
+		&mirrorGitlabNamespaceID, "gitlab-namespace-id",
+		0,
+		"Gitlab: ID of group or user namespace mirrors are created in, mirror URL path follows its current full path",
+	)
+	mirrorCmd.Flags().StringVar(
+		&mirrorNaming, "mirror-naming",
+		gitget.MirrorNamingFlat,
+		"Mirror repository naming [flat|namespace|full-path|full-path-dash|template]",
+	)
+	mirrorCmd.Flags().StringVar(
+		&mirrorNamingTemplate, "mirror-naming-template",
+		"",
+		"Go template of mirror repository name, used with '--mirror-naming template' (example: {{.Owner}}-{{.AltName}})",
+	)
+	mirrorCmd.Flags().StringVar(
+		&mirrorMode, "mode",
+		gitget.MirrorModePush,
+		"Mirror mode [push|native], native configures mirror provider to pull source repositories (only Gitlab)",
+	)
+	mirrorCmd.Flags().BoolVar(
+		&mirrorSyncMetadata, "sync-metadata",
+		false,
+		"Synchronise description, homepage, topics, default branch, visibility and archived state of mirrors",
+	)
+	mirrorCmd.Flags().StringVar(
+		&mirrorCacheDir, "mirror-cache-dir",
+		"",
+		"Directory to keep bare mirror repositories between runs, only changed repositories are pushed",
+	)
+	mirrorCmd.Flags().BoolVar(
+		&mirrorCacheFsck, "mirror-cache-fsck",
+		false,
+		"Check integrity of cached mirror repositories with 'git fsck', corrupted ones are cloned again",
+	)
+	mirrorCmd.Flags().StringSliceVar(
+		&mirrorRefs, "mirror-refs",
+		[]string{},
+		"Refs patterns or comma separated list of patterns to push instead of all refs (example: refs/heads/*,refs/tags/*)",
+	)
+	mirrorCmd.Flags().StringSliceVar(
+		&mirrorExcludeRefs, "mirror-exclude-refs",
+		[]string{},
+		"Refs patterns or comma separated list of patterns not to push (example: refs/pull/*,refs/merge-requests/*)",
+	)
+	mirrorCmd.Flags().BoolVar(
+		&mirrorNoDelete, "mirror-no-delete",
+		false,
+		"Do not delete refs in mirror repositories which are deleted in source repositories",
+	)
 	mirrorCmd.Flags().StringVarP(
 		&mirrorBitbucketProjectName, "bitbucket-mirror-project-name",
 		"b",
 		"",
 		"Bitbucket mirror project name (only effective for Bitbucket and is optional)",
 	)
+	mirrorCmd.Flags().StringVar(
+		&mirrorBitbucketServerURL, "bitbucket-server-url",
+		"",
+		"Bitbucket Data Center / Server base URL, when set Server REST API is used instead of Bitbucket Cloud (example: https://bitbucket.acme.com)",
+	)
+	mirrorCmd.Flags().StringVar(
+		&mirrorGithubCABundle, "github-ca-bundle",
+		"",
+		"Github: PEM encoded CA certificates bundle file used to verify Github Enterprise Server certificate",
+	)
+	mirrorCmd.Flags().StringVar(
+		&mirrorGithubTeam, "github-mirror-team",
+		"",
+		"Github: organization team slug to grant permission on mirror repositories",
+	)
+	mirrorCmd.Flags().StringVar(
+		&mirrorGithubTeamPermission, "github-mirror-team-permission",
+		"push",
+		"Github: team permission on mirror repositories [pull|triage|push|maintain|admin] or custom role name",
+	)
 }
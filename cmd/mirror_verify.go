@@ -0,0 +1,181 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package cmd provides logic for cli entrance point.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/isindir/git-get/credentials"
+	"github.com/isindir/git-get/gitget"
+	"github.com/spf13/cobra"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// mirrorVerifyCmd represents the mirror verify command
+var mirrorVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify that mirrors have the same refs as source repositories",
+	Long: `
+Verify that mirror repositories have the same refs as source repositories using configuration file.
+
+Refs of source repositories and their mirrors are compared with 'git ls-remote', nothing is cloned.
+Mirror URLs are computed the same way as by 'git-get mirror', so the same '--mirror-url',
+'--mirror-provider', '--mirror-naming' and refs filtering flags should be used.
+
+Notes:
+
+* Missing - source refs which are not in mirror.
+* Extra - mirror refs which are not in source, not reported with '--mirror-no-delete'.
+* Divergent - refs pointing to different commits or tags in source and mirror.
+* Symbolic HEAD and peeled tags are not compared, refs excluded from mirroring by '--mirror-refs',
+  '--mirror-exclude-refs' or Gitfile 'mirror_refs' and 'mirror_exclude_refs' fields are ignored.
+* Command exits with non zero status if any mirror is not consistent or can't be verified.`,
+	Example: `
+git-get mirror verify -f Gitfile -u "git@gitlab.com:acmeorg/mirrors"
+git-get mirror verify -c 8 -f Gitfile -u "git@github.com:acmeorg" -p "github" --mirror-exclude-refs "refs/pull/*"
+git-get mirror verify -f Gitfile -u "git@gitlab.com:acmeorg/mirrors" --mirror-naming namespace --report-file verify.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, cfgFile := range cfgFiles {
+			if _, err := os.Stat(cfgFile); os.IsNotExist(err) {
+				log.Fatalln(err)
+				os.Exit(1)
+			}
+		}
+		initLogging()
+		credentials.SetHostsFile(credentialsFile)
+		consistent := gitget.VerifyMirrors(
+			cfgFiles,
+			ignoreFiles,
+			concurrencyLevel,
+			gitCloudProviderRootURL,
+			gitCloudProvider,
+			httpsTokenAuth,
+			mirrorNaming,
+			mirrorNamingTemplate,
+			mirrorGitlabNamespaceID,
+			mirrorRefs,
+			mirrorExcludeRefs,
+			mirrorNoDelete,
+			mirrorReportFile,
+		)
+		if !consistent {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	mirrorCmd.AddCommand(mirrorVerifyCmd)
+
+	wdir, err := os.Getwd()
+	if err != nil {
+		log.Fatalln(err)
+		os.Exit(1)
+	}
+
+	defaultValue := filepath.Join(wdir, "Gitfile")
+	defaultIgnoreValue := fmt.Sprintf("%s.ignore", defaultValue)
+	mirrorVerifyCmd.Flags().StringSliceVarP(
+		&cfgFiles, "config-file",
+		"f",
+		[]string{defaultValue},
+		"Configuration file or comma separated list of files")
+	mirrorVerifyCmd.Flags().StringSliceVarP(
+		&ignoreFiles, "ignore-file",
+		"i",
+		[]string{defaultIgnoreValue},
+		"Ignore file or comma separated list of files")
+	mirrorVerifyCmd.Flags().StringVarP(
+		&logLevel, "log-level",
+		"l",
+		"info",
+		"Logging level [debug|info|warn|error|fatal|panic]",
+	)
+	mirrorVerifyCmd.Flags().StringVar(
+		&credentialsFile, "credentials-file",
+		"",
+		"Credentials hosts file with per host credential sources (default ~/.config/git-get/hosts.yaml)",
+	)
+	mirrorVerifyCmd.Flags().BoolVar(
+		&httpsTokenAuth, "https-token-auth",
+		false,
+		"Pass provider API token to git for https source and mirror repository URLs",
+	)
+	mirrorVerifyCmd.Flags().IntVarP(
+		&concurrencyLevel, "concurrency-level",
+		"c",
+		1,
+		"Git get concurrency level",
+	)
+	mirrorVerifyCmd.Flags().StringVar(
+		&mirrorReportFile, "report-file",
+		"",
+		"Write missing, extra and divergent refs of every repository to JSON file",
+	)
+	mirrorVerifyCmd.Flags().StringVarP(
+		&gitCloudProviderRootURL, "mirror-url",
+		"u",
+		"",
+		"Private Mirror URL prefix repositories are mirrored to (example: git@github.com:acmeorg)",
+	)
+	mirrorVerifyCmd.Flags().StringVarP(
+		&gitCloudProvider, "mirror-provider",
+		"p",
+		"gitlab",
+		"Git mirror provider name [gitlab|github|bitbucket]",
+	)
+	mirrorVerifyCmd.Flags().Int64Var(
+		&mirrorGitlabNamespaceID, "gitlab-namespace-id",
+		0,
+		"Gitlab: ID of group or user namespace mirrors are created in, mirror URL path follows its current full path",
+	)
+	mirrorVerifyCmd.Flags().StringVar(
+		&mirrorNaming, "mirror-naming",
+		gitget.MirrorNamingFlat,
+		"Mirror repository naming [flat|namespace|full-path|full-path-dash|template]",
+	)
+	mirrorVerifyCmd.Flags().StringVar(
+		&mirrorNamingTemplate, "mirror-naming-template",
+		"",
+		"Go template of mirror repository name, used with '--mirror-naming template' (example: {{.Owner}}-{{.AltName}})",
+	)
+	mirrorVerifyCmd.Flags().StringSliceVar(
+		&mirrorRefs, "mirror-refs",
+		[]string{},
+		"Refs patterns or comma separated list of patterns mirrored instead of all refs (example: refs/heads/*,refs/tags/*)",
+	)
+	mirrorVerifyCmd.Flags().StringSliceVar(
+		&mirrorExcludeRefs, "mirror-exclude-refs",
+		[]string{},
+		"Refs patterns or comma separated list of patterns not mirrored (example: refs/pull/*,refs/merge-requests/*)",
+	)
+	mirrorVerifyCmd.Flags().BoolVar(
+		&mirrorNoDelete, "mirror-no-delete",
+		false,
+		"Mirror refs deleted in source are kept, don't report them",
+	)
+}
@@ -28,6 +28,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/isindir/git-get/credentials"
 	"github.com/isindir/git-get/gitget"
 	"github.com/spf13/cobra"
 
@@ -43,20 +44,49 @@ var (
 	logLevel                string
 	stayOnRef               bool
 	shallow                 bool
+	shallowKeepGit          bool
 	concurrencyLevel        int
 	pushMirror              bool
 	dryRun                  bool
 	gitCloudProviderRootURL string
+	credentialsFile         string
+	httpsTokenAuth          bool
 	targetClonePath         string
 	defaultMainBranch       string
 	status                  bool
+	submodules              string
+	referenceCache          string
 	gitCloudProvider        string
 )
 
+// Export specific vars
+var (
+	exportOutputDir string
+	exportFormat    string
+)
+
+// Bundle specific vars
+var bundleDir string
+
 // Mirroring specific vars
 var (
 	mirrorVisibilityMode       string
 	mirrorBitbucketProjectName string
+	mirrorBitbucketServerURL   string
+	mirrorGithubCABundle       string
+	mirrorGithubTeam           string
+	mirrorGithubTeamPermission string
+	mirrorGitlabNamespaceID    int64
+	mirrorNaming               string
+	mirrorNamingTemplate       string
+	mirrorCacheDir             string
+	mirrorCacheFsck            bool
+	mirrorRefs                 []string
+	mirrorExcludeRefs          []string
+	mirrorNoDelete             bool
+	mirrorMode                 string
+	mirrorSyncMetadata         bool
+	mirrorReportFile           string
 )
 
 var levels = map[string]log.Level{
@@ -87,7 +117,11 @@ git get -c 12 -f Gitfile
 git get -c 12 -f Gitfile.1 -f Gitfile.2 -f Gitfile.3,Gitfile.4
 git get -c 12 -f Gitfile -i Gitfile.ignore.1 -i Gitfile.ignore.2
 git get -c 8 -f Gitfile --status -i Gitfile.ignore -l panic \
-  | awk '$0 ~ /REPOSITORY/ || $3 ~ /true/ { print $0 }'`,
+  | awk '$0 ~ /REPOSITORY/ || $3 ~ /true/ { print $0 }'
+GITLAB_TOKEN=xxx git get -c 8 -f Gitfile --shallow --https-token-auth
+git get -c 8 -f Gitfile --submodules recursive
+git get -c 8 -f Gitfile --shallow-keep-git
+git get -c 8 -f Gitfile --shallow --reference-cache /var/cache/git-get`,
 	Run: func(cmd *cobra.Command, args []string) {
 		for _, cfgFile := range cfgFiles {
 			if _, err := os.Stat(cfgFile); os.IsNotExist(err) {
@@ -96,14 +130,19 @@ git get -c 8 -f Gitfile --status -i Gitfile.ignore -l panic \
 			}
 		}
 		initLogging()
+		credentials.SetHostsFile(credentialsFile)
 		gitget.GetRepositories(
 			cfgFiles,
 			ignoreFiles,
 			concurrencyLevel,
 			stayOnRef,
 			shallow,
+			shallowKeepGit,
 			defaultMainBranch,
 			status,
+			httpsTokenAuth,
+			submodules,
+			referenceCache,
 		)
 	},
 }
@@ -160,6 +199,18 @@ func init() {
 		"s",
 		false,
 		"Shallow clone, can be used in CI to fetch dependencies by ref")
+	rootCmd.Flags().BoolVar(
+		&shallowKeepGit, "shallow-keep-git",
+		false,
+		"Shallow clone keeping '.git', existing clones are refreshed with 'git fetch --depth 1' and hard reset to ref")
+	rootCmd.Flags().StringVar(
+		&submodules, "submodules",
+		gitget.SubmodulesNone,
+		"Submodules of cloned and refreshed repositories to initialise and update [none|init|recursive]")
+	rootCmd.Flags().StringVar(
+		&referenceCache, "reference-cache",
+		"",
+		"Directory with full bare mirrors shared by clones (ignoring shallow and filter), only objects missing in cache are fetched from remote")
 	rootCmd.Flags().BoolVar(
 		&status, "status",
 		false,
@@ -169,4 +220,12 @@ func init() {
 		"b",
 		"master",
 		"Default main branch")
+	rootCmd.Flags().BoolVar(
+		&httpsTokenAuth, "https-token-auth",
+		false,
+		"Pass provider API token to git for https repository URLs (see --credentials-file)")
+	rootCmd.Flags().StringVar(
+		&credentialsFile, "credentials-file",
+		"",
+		"Credentials hosts file with per host credential sources (default ~/.config/git-get/hosts.yaml)")
 }
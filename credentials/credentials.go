@@ -0,0 +1,283 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package credentials resolves git provider API credentials per host from environment
+// variables, hosts file, git credential helpers or external commands.
+//
+// Hosts file example (~/.config/git-get/hosts.yaml):
+//
+//	gitlab.com:
+//	  source: env
+//	gitlab.acme.com:
+//	  provider: gitlab
+//	  token_env: ACME_GITLAB_TOKEN
+//	ghe.corp.example:
+//	  provider: github
+//	github.com:
+//	  source: git-credential
+//	bitbucket.acme.com:
+//	  username: svc-mirror
+//	  command: ["vault", "kv", "get", "-field=token", "secret/bitbucket"]
+package credentials
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Credential sources
+const (
+	SourceEnv           = "env"            // environment variables
+	SourceFile          = "file"           // username and token stored in hosts file
+	SourceGitCredential = "git-credential" // `git credential fill`
+	SourceCommand       = "command"        // external command printing token to stdout
+)
+
+// HostCredentials - credentials source configuration of a single host in hosts file
+type HostCredentials struct {
+	Provider    string   `yaml:"provider,omitempty"`     // git provider of the host [github|gitlab|bitbucket]
+	Source      string   `yaml:"source,omitempty"`       // one of env, file, git-credential, command (inferred if omitted)
+	Username    string   `yaml:"username,omitempty"`     // username for file, git-credential and command sources
+	Token       string   `yaml:"token,omitempty"`        // token for file source
+	UsernameEnv string   `yaml:"username_env,omitempty"` // overrides provider username environment variable
+	TokenEnv    string   `yaml:"token_env,omitempty"`    // overrides provider token environment variable
+	Command     []string `yaml:"command,omitempty"`      // command and arguments for command source
+}
+
+// Credentials - resolved credentials, Username is empty if provider does not need it
+type Credentials struct {
+	Username string
+	Token    string
+}
+
+var (
+	hostsFile         = DefaultHostsFile()
+	hostsFileRequired = false
+	hostsOnce         sync.Once
+	hosts             map[string]HostCredentials
+	hostsErr          error
+)
+
+// DefaultHostsFile - returns default hosts file location ~/.config/git-get/hosts.yaml
+func DefaultHostsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".config", "git-get", "hosts.yaml")
+}
+
+// SetHostsFile - overrides default hosts file location, file specified this way must exist
+func SetHostsFile(path string) {
+	if path == "" {
+		return
+	}
+	hostsFile = path
+	hostsFileRequired = true
+	hostsOnce = sync.Once{}
+}
+
+func loadHosts() (map[string]HostCredentials, error) {
+	hostsOnce.Do(func() {
+		hosts = map[string]HostCredentials{}
+		hostsErr = nil
+		if hostsFile == "" {
+			return
+		}
+
+		data, err := os.ReadFile(hostsFile)
+		if err != nil {
+			if os.IsNotExist(err) && !hostsFileRequired {
+				log.Debugf("Credentials hosts file '%s' not found, using environment variables", hostsFile)
+				return
+			}
+			hostsErr = err
+			return
+		}
+
+		var fileHosts map[string]HostCredentials
+		if err := yaml.Unmarshal(data, &fileHosts); err != nil {
+			hostsErr = fmt.Errorf("%s: %w", hostsFile, err)
+			return
+		}
+		// host names are case insensitive, hosts are looked up lowercased
+		for host, hostCredentials := range fileHosts {
+			hosts[strings.ToLower(host)] = hostCredentials
+		}
+	})
+
+	return hosts, hostsErr
+}
+
+// source - returns configured or inferred credentials source
+func (host *HostCredentials) source() string {
+	switch {
+	case host.Source != "":
+		return host.Source
+	case len(host.Command) > 0:
+		return SourceCommand
+	case host.Token != "":
+		return SourceFile
+	default:
+		return SourceEnv
+	}
+}
+
+// Provider - returns git provider configured for the host in hosts file, empty string if the host
+// is not configured or hosts file can't be loaded
+func Provider(host string) string {
+	hostList, err := loadHosts()
+	if err != nil {
+		return ""
+	}
+
+	return hostList[strings.ToLower(host)].Provider
+}
+
+// Lookup - resolves credentials for host: uses hosts file entry if host is configured there,
+// otherwise reads provider environment variables `usernameEnv` (optional) and `tokenEnv`
+func Lookup(host, usernameEnv, tokenEnv string) (Credentials, error) {
+	hostList, err := loadHosts()
+	if err != nil {
+		return Credentials{}, fmt.Errorf("can't load credentials hosts file: %w", err)
+	}
+
+	hostCredentials, found := hostList[strings.ToLower(host)]
+	if !found {
+		return fromEnv(usernameEnv, tokenEnv)
+	}
+	log.Debugf("Using '%s' credentials source for host '%s'", hostCredentials.source(), host)
+
+	switch hostCredentials.source() {
+	case SourceEnv:
+		if hostCredentials.UsernameEnv != "" {
+			usernameEnv = hostCredentials.UsernameEnv
+		}
+		if hostCredentials.TokenEnv != "" {
+			tokenEnv = hostCredentials.TokenEnv
+		}
+		return fromEnv(usernameEnv, tokenEnv)
+	case SourceFile:
+		if hostCredentials.Token == "" {
+			return Credentials{}, fmt.Errorf("token for host '%s' is not set in '%s'", host, hostsFile)
+		}
+		return Credentials{Username: hostCredentials.Username, Token: hostCredentials.Token}, nil
+	case SourceGitCredential:
+		return fromGitCredential(host, hostCredentials.Username)
+	case SourceCommand:
+		return fromCommand(host, hostCredentials.Username, hostCredentials.Command)
+	default:
+		return Credentials{}, fmt.Errorf(
+			"unknown credentials source '%s' for host '%s' in '%s'", hostCredentials.Source, host, hostsFile)
+	}
+}
+
+func fromEnv(usernameEnv, tokenEnv string) (Credentials, error) {
+	var credentials Credentials
+	var tokenFound bool
+
+	credentials.Token, tokenFound = os.LookupEnv(tokenEnv)
+	if !tokenFound {
+		return credentials, fmt.Errorf("environment variable %s not found", tokenEnv)
+	}
+	if usernameEnv != "" {
+		credentials.Username = os.Getenv(usernameEnv)
+	}
+
+	return credentials, nil
+}
+
+// fromGitCredential - asks configured git credential helpers for https credentials of the host
+func fromGitCredential(host, username string) (Credentials, error) {
+	var input bytes.Buffer
+	fmt.Fprintf(&input, "protocol=https\nhost=%s\n", host)
+	if username != "" {
+		fmt.Fprintf(&input, "username=%s\n", username)
+	}
+	input.WriteString("\n")
+
+	var stdoutb, erroutb bytes.Buffer
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = &input
+	cmd.Stdout = &stdoutb
+	cmd.Stderr = &erroutb
+	// never prompt for credentials interactively
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if err := cmd.Run(); err != nil {
+		return Credentials{}, fmt.Errorf(
+			"git credential fill for host '%s' failed: %s: %s", host, err, strings.TrimSpace(erroutb.String()))
+	}
+
+	credentials := Credentials{Username: username}
+	for _, line := range strings.Split(stdoutb.String(), "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "username":
+			credentials.Username = value
+		case "password":
+			credentials.Token = value
+		}
+	}
+	if credentials.Token == "" {
+		return credentials, fmt.Errorf("git credential helper returned no password for host '%s'", host)
+	}
+
+	return credentials, nil
+}
+
+// fromCommand - runs external command, which prints token to stdout, GIT_GET_HOST environment
+// variable is passed to the command, so that single script can serve multiple hosts
+func fromCommand(host, username string, command []string) (Credentials, error) {
+	if len(command) == 0 {
+		return Credentials{}, fmt.Errorf("credentials command for host '%s' is not set", host)
+	}
+
+	var stdoutb, erroutb bytes.Buffer
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdout = &stdoutb
+	cmd.Stderr = &erroutb
+	cmd.Env = append(os.Environ(), "GIT_GET_HOST="+host)
+	if err := cmd.Run(); err != nil {
+		return Credentials{}, fmt.Errorf(
+			"credentials command '%s' for host '%s' failed: %s: %s",
+			command[0], host, err, strings.TrimSpace(erroutb.String()))
+	}
+
+	token := strings.TrimSpace(stdoutb.String())
+	if token == "" {
+		return Credentials{}, fmt.Errorf("credentials command '%s' for host '%s' printed no token", command[0], host)
+	}
+
+	return Credentials{Username: username, Token: token}, nil
+}
@@ -0,0 +1,151 @@
+//go:build !integration
+// +build !integration
+
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeHostsFile(t *testing.T, content string) {
+	hostsPath := filepath.Join(t.TempDir(), "hosts.yaml")
+	require.NoError(t, os.WriteFile(hostsPath, []byte(content), 0o600))
+
+	originalFile, originalRequired := hostsFile, hostsFileRequired
+	t.Cleanup(func() {
+		hostsFile, hostsFileRequired = originalFile, originalRequired
+		hostsOnce = sync.Once{}
+	})
+	SetHostsFile(hostsPath)
+}
+
+func TestLookup_EnvFallback(t *testing.T) {
+	writeHostsFile(t, "gitlab.acme.com:\n  token: acme-token\n")
+	t.Setenv("GITLAB_TOKEN", "env-token")
+
+	credentials, err := Lookup("gitlab.com", "", "GITLAB_TOKEN")
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Token: "env-token"}, credentials)
+
+	credentials, err = Lookup("gitlab.acme.com", "", "GITLAB_TOKEN")
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Token: "acme-token"}, credentials)
+}
+
+func TestLookup_MixedCaseHost(t *testing.T) {
+	writeHostsFile(t, "GitLab.Acme.com:\n  provider: gitlab\n  token: acme-token\n")
+	t.Setenv("GITLAB_TOKEN", "env-token")
+
+	credentials, err := Lookup("gitlab.acme.com", "", "GITLAB_TOKEN")
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Token: "acme-token"}, credentials)
+	assert.Equal(t, "gitlab", Provider("GITLAB.acme.com"))
+}
+
+func TestLookup_EnvMissing(t *testing.T) {
+	writeHostsFile(t, "{}\n")
+	os.Unsetenv("GIT_GET_TEST_MISSING_TOKEN")
+
+	_, err := Lookup("gitlab.com", "", "GIT_GET_TEST_MISSING_TOKEN")
+	assert.ErrorContains(t, err, "environment variable GIT_GET_TEST_MISSING_TOKEN not found")
+}
+
+func TestLookup_EnvOverride(t *testing.T) {
+	writeHostsFile(t, `
+bitbucket.acme.com:
+  source: env
+  username_env: ACME_BITBUCKET_USERNAME
+  token_env: ACME_BITBUCKET_TOKEN
+`)
+	t.Setenv("BITBUCKET_USERNAME", "cloud-user")
+	t.Setenv("BITBUCKET_TOKEN", "cloud-token")
+	t.Setenv("ACME_BITBUCKET_USERNAME", "acme-user")
+	t.Setenv("ACME_BITBUCKET_TOKEN", "acme-token")
+
+	credentials, err := Lookup("Bitbucket.Acme.com", "BITBUCKET_USERNAME", "BITBUCKET_TOKEN")
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "acme-user", Token: "acme-token"}, credentials)
+}
+
+func TestLookup_File(t *testing.T) {
+	writeHostsFile(t, `
+github.com:
+  source: file
+  username: johndoe
+  token: ghp_file
+github.acme.com:
+  source: file
+`)
+
+	credentials, err := Lookup("github.com", "", "GITHUB_TOKEN")
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "johndoe", Token: "ghp_file"}, credentials)
+
+	_, err = Lookup("github.acme.com", "", "GITHUB_TOKEN")
+	assert.ErrorContains(t, err, "token for host 'github.acme.com' is not set")
+}
+
+func TestLookup_Command(t *testing.T) {
+	writeHostsFile(t, `
+gitlab.acme.com:
+  username: svc
+  command: ["sh", "-c", "echo token-for-$GIT_GET_HOST"]
+gitlab.broken.com:
+  command: ["sh", "-c", "echo failure >&2; exit 3"]
+`)
+
+	credentials, err := Lookup("gitlab.acme.com", "", "GITLAB_TOKEN")
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "svc", Token: "token-for-gitlab.acme.com"}, credentials)
+
+	_, err = Lookup("gitlab.broken.com", "", "GITLAB_TOKEN")
+	assert.ErrorContains(t, err, "failure")
+}
+
+func TestLookup_GitCredential(t *testing.T) {
+	writeHostsFile(t, "gitlab.acme.com:\n  source: git-credential\n")
+	// configure credential helper via environment, so that user git config is not modified
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "credential.helper")
+	t.Setenv("GIT_CONFIG_VALUE_0", "!f() { echo username=helper-user; echo password=helper-token; }; f")
+
+	credentials, err := Lookup("gitlab.acme.com", "", "GITLAB_TOKEN")
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "helper-user", Token: "helper-token"}, credentials)
+}
+
+func TestLookup_UnknownSource(t *testing.T) {
+	writeHostsFile(t, "gitlab.acme.com:\n  source: keychain\n")
+
+	_, err := Lookup("gitlab.acme.com", "", "GITLAB_TOKEN")
+	assert.ErrorContains(t, err, "unknown credentials source 'keychain'")
+}
+
+func TestLookup_MissingRequiredHostsFile(t *testing.T) {
+	writeHostsFile(t, "{}\n")
+	SetHostsFile(filepath.Join(t.TempDir(), "missing.yaml"))
+
+	_, err := Lookup("gitlab.com", "", "GITLAB_TOKEN")
+	assert.Error(t, err)
+}
+
+func TestProvider(t *testing.T) {
+	writeHostsFile(t, `
+ghe.corp.example:
+  provider: github
+git.corp.example:
+  token: corp-token
+`)
+
+	assert.Equal(t, "github", Provider("GHE.corp.example"))
+	assert.Equal(t, "", Provider("git.corp.example"))
+	assert.Equal(t, "", Provider("gitlab.com"))
+}
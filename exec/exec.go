@@ -2,6 +2,7 @@ package exec
 
 import (
 	"bytes"
+	"os"
 	"os/exec"
 )
 
@@ -11,7 +12,22 @@ type ShellRunnerI interface {
 	ExecGitCommand(args []string, stdoutb *bytes.Buffer, erroutb *bytes.Buffer, dir string) (cmd *exec.Cmd, err error)
 }
 
-type ShellRunner struct{}
+type ShellRunner struct {
+	// Env - extra environment variables passed to git in addition to current process environment,
+	// used for secrets which must not appear in command line arguments or logs
+	Env []string
+}
+
+// WithEnv returns shell runner passing extra environment variables `env` to git in addition to the ones
+// of `runner`, runners other than ShellRunner are returned as is
+func WithEnv(runner ShellRunnerI, env ...string) ShellRunnerI {
+	shellRunner, ok := runner.(*ShellRunner)
+	if !ok {
+		return runner
+	}
+
+	return &ShellRunner{Env: append(append([]string{}, shellRunner.Env...), env...)}
+}
 
 // ExecGitCommand executes git with flags passed as `args` and can change working directory if `dir` is passed
 func (repo *ShellRunner) ExecGitCommand(
@@ -21,6 +37,9 @@ func (repo *ShellRunner) ExecGitCommand(
 	dir string,
 ) (cmd *exec.Cmd, err error) {
 	cmd = exec.Command(gitCmd, args...)
+	if len(repo.Env) > 0 {
+		cmd.Env = append(os.Environ(), repo.Env...)
+	}
 
 	if stdoutb != nil {
 		cmd.Stdout = stdoutb
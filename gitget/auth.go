@@ -0,0 +1,226 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/isindir/git-get/credentials"
+	"github.com/isindir/git-get/exec"
+	"github.com/isindir/git-get/github"
+)
+
+// genericTokenEnv - token environment variable for hosts of unknown provider
+const genericTokenEnv = "GIT_GET_TOKEN"
+
+// publicProviderHosts - providers of public git hosting services
+var publicProviderHosts = map[string]string{
+	"github.com":    "github",
+	"gitlab.com":    "gitlab",
+	"bitbucket.org": "bitbucket",
+}
+
+type authHeaderResult struct {
+	header string
+	err    error
+}
+
+var (
+	authHeaderCache = map[string]authHeaderResult{}
+	authHeaderMutex sync.Mutex
+	// providers of the hosts configured for the run with mirror or config-gen provider flags
+	runProviderHosts = map[string]string{}
+	// GitHub API clients of the hosts configured for the run, their tokens are used for git over https
+	runGithubClients  = map[string]*github.GitGetGithub{}
+	runProvidersMutex sync.Mutex
+)
+
+// setHostProvider - records provider of the `rootURL` host configured for the run, `githubClient` is
+// set for GitHub hosts, so that git uses the same token as API calls, including GitHub App tokens
+func setHostProvider(rootURL, provider string, githubClient *github.GitGetGithub) {
+	// dry-run mirror doesn't require mirror URL
+	host := strings.ToLower(gitURLHost(rootURL))
+	if host == "" {
+		return
+	}
+
+	runProvidersMutex.Lock()
+	defer runProvidersMutex.Unlock()
+
+	runProviderHosts[host] = provider
+	if githubClient != nil {
+		runGithubClients[host] = githubClient
+	}
+}
+
+// runGithubClient - returns GitHub API client of the host configured for the run, nil if there is none
+func runGithubClient(host string) *github.GitGetGithub {
+	runProvidersMutex.Lock()
+	defer runProvidersMutex.Unlock()
+
+	return runGithubClients[strings.ToLower(host)]
+}
+
+// hostProvider - returns git provider name of the host: `provider` of the host in credentials hosts
+// file, provider configured for the run for its host or provider of public hosting service, empty
+// string if provider is unknown
+func hostProvider(host string) string {
+	host = strings.ToLower(host)
+	if provider := credentials.Provider(host); provider != "" {
+		return provider
+	}
+
+	runProvidersMutex.Lock()
+	provider, found := runProviderHosts[host]
+	runProvidersMutex.Unlock()
+	if found {
+		return provider
+	}
+
+	return publicProviderHosts[host]
+}
+
+// providerTokenEnv - provider environment variables used for host missing from credentials hosts file
+func providerTokenEnv(provider string) (usernameEnv, tokenEnv string) {
+	switch provider {
+	case "github":
+		return "", "GITHUB_TOKEN"
+	case "gitlab":
+		return "", "GITLAB_TOKEN"
+	case "bitbucket":
+		return "BITBUCKET_USERNAME", "BITBUCKET_TOKEN"
+	default:
+		return "", genericTokenEnv
+	}
+}
+
+// tokenUsername - username providers accept with API token in https basic authentication,
+// empty string means token is sent as bearer token
+func tokenUsername(provider string) string {
+	switch provider {
+	case "github":
+		return "x-access-token"
+	case "gitlab":
+		return "oauth2"
+	default:
+		return ""
+	}
+}
+
+// hostToken - returns username and token git uses for https authentication on the host, GitHub hosts
+// configured for the run use token of their API client
+func hostToken(host string) (username, token string, err error) {
+	provider := hostProvider(host)
+	if githubClient := runGithubClient(host); provider == "github" && githubClient != nil {
+		token, err = githubClient.Token(context.Background(), "", host)
+		return tokenUsername(provider), token, err
+	}
+
+	usernameEnv, tokenEnv := providerTokenEnv(provider)
+	hostCredentials, err := credentials.Lookup(host, usernameEnv, tokenEnv)
+	if err != nil {
+		return "", "", err
+	}
+	username = hostCredentials.Username
+	if username == "" {
+		username = tokenUsername(provider)
+	}
+
+	return username, hostCredentials.Token, nil
+}
+
+// authHeader - returns http Authorization header for the host, resolved credentials are cached
+// for the run, so that credential helpers and commands are not executed for every repository,
+// GitHub App installation tokens expire and are not cached here
+func authHeader(host string) (string, error) {
+	authHeaderMutex.Lock()
+	defer authHeaderMutex.Unlock()
+
+	if result, found := authHeaderCache[host]; found {
+		return result.header, result.err
+	}
+
+	var result authHeaderResult
+	username, token, err := hostToken(host)
+	if err != nil {
+		result.err = err
+	} else if username == "" {
+		result.header = "Authorization: Bearer " + token
+	} else {
+		result.header = "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+token))
+	}
+	if runGithubClient(host) == nil {
+		authHeaderCache[host] = result
+	}
+
+	return result.header, result.err
+}
+
+// gitAuthEnv - returns environment configuring git `http.<url>.extraHeader` with provider token for
+// every https url, so that token is neither written to .git/config nor visible in command arguments
+func gitAuthEnv(repoSha string, gitURLs ...string) []string {
+	// keep configuration user may already pass to git via environment
+	configCount, _ := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT"))
+	env := []string{"GIT_TERMINAL_PROMPT=0"}
+	seen := map[string]bool{}
+
+	for _, gitURL := range gitURLs {
+		parsedURL, err := url.Parse(gitURL)
+		if err != nil || parsedURL.Scheme != HTTPS || seen[parsedURL.Host] {
+			continue
+		}
+		seen[parsedURL.Host] = true
+
+		header, err := authHeader(parsedURL.Hostname())
+		if err != nil {
+			log.Warnf("%s: No token for '%s', relying on git credentials configuration: %s", repoSha, parsedURL.Host, err)
+			continue
+		}
+		env = append(env,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=http.https://%s/.extraHeader", configCount, parsedURL.Host),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", configCount, header),
+		)
+		configCount++
+	}
+
+	return append(env, fmt.Sprintf("GIT_CONFIG_COUNT=%d", configCount))
+}
+
+// SetHTTPSTokenAuth - if enabled, switches repository to dedicated shell runner passing provider
+// tokens to git for https source and mirror urls
+func (repo *Repo) SetHTTPSTokenAuth() {
+	if !httpsTokenAuth {
+		return
+	}
+
+	repo.SetShellRunner(&exec.ShellRunner{Env: gitAuthEnv(repo.sha, repo.URL, repo.mirrorURL)})
+}
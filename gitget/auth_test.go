@@ -0,0 +1,142 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/isindir/git-get/exec"
+	"github.com/isindir/git-get/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func resetAuthHeaderCache(t *testing.T) {
+	reset := func() {
+		authHeaderCache = map[string]authHeaderResult{}
+		runProviderHosts = map[string]string{}
+		runGithubClients = map[string]*github.GitGetGithub{}
+	}
+	t.Cleanup(reset)
+	reset()
+}
+
+func basicHeader(username, token string) string {
+	return "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+token))
+}
+
+func Test_authHeader(t *testing.T) {
+	resetAuthHeaderCache(t)
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+	t.Setenv("GITLAB_TOKEN", "gl-token")
+	t.Setenv("BITBUCKET_USERNAME", "bb-user")
+	t.Setenv("BITBUCKET_TOKEN", "bb-token")
+	t.Setenv("GIT_GET_TOKEN", "generic-token")
+
+	testCases := []struct {
+		host     string
+		expected string
+	}{
+		{host: "github.com", expected: basicHeader("x-access-token", "gh-token")},
+		{host: "gitlab.acme.com", expected: basicHeader("oauth2", "gl-token")},
+		{host: "bitbucket.org", expected: basicHeader("bb-user", "bb-token")},
+		{host: "bitbucket.acme.com", expected: basicHeader("bb-user", "bb-token")},
+		// provider of self-hosted instance is not guessed by host name
+		{host: "gitlab.corp.example", expected: "Authorization: Bearer generic-token"},
+		{host: "git.acme.com", expected: "Authorization: Bearer generic-token"},
+	}
+	setHostProvider("git@gitlab.acme.com:acme/mirrors", "gitlab", nil)
+	setHostProvider("ssh://git@bitbucket.acme.com:7999/MIRRORS", "bitbucket", nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.host, func(t *testing.T) {
+			header, err := authHeader(tc.host)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, header)
+		})
+	}
+}
+
+func Test_authHeader_GithubRunClient(t *testing.T) {
+	resetAuthHeaderCache(t)
+	t.Setenv("GITHUB_TOKEN", "ghe-token")
+	t.Setenv("GIT_GET_TOKEN", "generic-token")
+
+	githubClient := &github.GitGetGithub{}
+	githubClient.InitTokenForHost("ghe.corp.example")
+	setHostProvider("git@ghe.corp.example:acme", "github", githubClient)
+
+	assert.Equal(t, "github", hostProvider("GHE.corp.example"))
+	header, err := authHeader("ghe.corp.example")
+	assert.NoError(t, err)
+	assert.Equal(t, basicHeader("x-access-token", "ghe-token"), header)
+	// token of the API client may expire and is not cached
+	assert.NotContains(t, authHeaderCache, "ghe.corp.example")
+}
+
+func Test_gitAuthEnv(t *testing.T) {
+	resetAuthHeaderCache(t)
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+
+	env := gitAuthEnv(
+		"sha",
+		"https://github.com/acme/api.git",
+		"git@gitlab.com:acme/api.git",
+		"https://github.com/acme/web.git",
+		"",
+	)
+
+	assert.Equal(t, []string{
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_CONFIG_KEY_1=http.https://github.com/.extraHeader",
+		"GIT_CONFIG_VALUE_1=" + basicHeader("x-access-token", "gh-token"),
+		"GIT_CONFIG_COUNT=2",
+	}, env)
+}
+
+func Test_gitAuthEnv_HeaderSentByGit(t *testing.T) {
+	resetAuthHeaderCache(t)
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	t.Setenv("GIT_GET_TOKEN", "secret-token")
+	t.Setenv("GIT_SSL_NO_VERIFY", "true")
+	t.Setenv("GIT_CONFIG_COUNT", "")
+	repoURL := server.URL + "/acme/api.git"
+
+	var serr bytes.Buffer
+	runner := &exec.ShellRunner{Env: gitAuthEnv("sha", repoURL)}
+	_, err := runner.ExecGitCommand([]string{"ls-remote", repoURL}, nil, &serr, "")
+
+	assert.Error(t, err)
+	assert.Equal(t, "Bearer secret-token", authorization)
+	assert.False(t, strings.Contains(serr.String(), "secret-token"))
+}
+
+func Test_Repo_SetHTTPSTokenAuth(t *testing.T) {
+	repo := Repo{URL: "https://github.com/acme/api.git"}
+	repo.SetShellRunner(shellRunner)
+
+	httpsTokenAuth = false
+	repo.SetHTTPSTokenAuth()
+	assert.Equal(t, shellRunner, *repo.executor)
+
+	resetAuthHeaderCache(t)
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+	httpsTokenAuth = true
+	defer func() { httpsTokenAuth = false }()
+	repo.SetHTTPSTokenAuth()
+	runner, ok := (*repo.executor).(*exec.ShellRunner)
+	assert.True(t, ok)
+	assert.Contains(t, runner.Env, "GIT_CONFIG_VALUE_0="+basicHeader("x-access-token", "gh-token"))
+}
@@ -0,0 +1,218 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// bundleExtension - extension of bundle files, bundles are laid out as exported archives
+const bundleExtension = "bundle"
+
+// CreateBundle writes `git bundle` with all refs of the repository next to its export path, refs are
+// fetched to temporary mirror clone, commit of the ref is recorded if the ref exists in repository
+func (repo *Repo) CreateBundle(workDir string) bool {
+	bundleFile := repo.exportTarget(bundleExtension)
+	clonePath := filepath.Join(workDir, repo.sha+".git")
+	defer os.RemoveAll(clonePath)
+
+	if !repo.CloneMirror(clonePath) {
+		return false
+	}
+
+	var outb, serr bytes.Buffer
+	_, err := (*repo.executor).ExecGitCommand([]string{"rev-parse", repo.Ref + "^{commit}"}, &outb, &serr, clonePath)
+	if err != nil {
+		log.Warnf("%s: Unable to resolve ref '%s', bundle commit is not recorded: %v %v",
+			repo.sha, repo.Ref, err, strings.TrimSpace(serr.String()))
+	} else {
+		repo.commit = strings.TrimSpace(outb.String())
+	}
+
+	log.Infof("%s: Create bundle '%s'", repo.sha, bundleFile)
+	return repo.exportGitCommand([]string{"bundle", "create", bundleFile, "--all"}, nil, clonePath)
+}
+
+// bundleFile - returns bundle file of the repository in bundle directory, bundle directory layout
+// follows Gitfile paths ( <bundle dir>/<path>/<altname>.bundle )
+func (repo *Repo) bundleFile(bundleDir string) string {
+	return filepath.Join(bundleDir, repo.Path, repo.GetRepoLocalName()+"."+bundleExtension)
+}
+
+// RestoreBundle clones repository at Gitfile path from the bundle and points `origin` to repository
+// URL, so that clone can be refreshed from network later, existing clones are not changed
+func (repo *Repo) RestoreBundle(bundleFile string) bool {
+	if repo.RepoPathExists() {
+		log.Warnf("%s: path '%s' exists, skipping restore from bundle", repo.sha, repo.fullPath)
+		return true
+	}
+
+	log.Infof("%s: Restore repository '%s' from bundle '%s'", repo.sha, repo.URL, bundleFile)
+	return repo.exportGitCommand([]string{"clone", "--branch", repo.Ref, bundleFile, repo.fullPath}, nil, "") &&
+		repo.exportGitCommand([]string{"remote", "set-url", "origin", repo.URL}, nil, repo.fullPath)
+}
+
+func createBundlesFromConfigInParallel(
+	repoList *RepoList,
+	ignoreRepoList []Repo,
+	concurrencyLevel int,
+	bundleDir string,
+) {
+	throttle := make(chan int, concurrencyLevel)
+
+	var wait sync.WaitGroup
+
+	workDir, err := os.MkdirTemp("", "gitgetbundle")
+	if err != nil {
+		log.Fatalf("Error: %s, while creating temporary directory", err)
+		os.Exit(1)
+	}
+	defer os.RemoveAll(workDir)
+
+	for i := 0; i < len(*repoList); i++ {
+		throttle <- 1
+		wait.Add(1)
+
+		go func(repository *Repo, iwait *sync.WaitGroup, ithrottle chan int) {
+			defer iwait.Done()
+
+			if !ignoreThisRepo(repository.URL, ignoreRepoList) {
+				repository.PrepareForExport(bundleDir)
+				log.Debugf("%s: process repo: '%s'", repository.sha, repository.URL)
+				repository.CreateBundle(workDir)
+				repository.status.Processed = true
+			}
+
+			<-ithrottle
+		}(&(*repoList)[i], &wait, throttle)
+	}
+
+	wait.Wait()
+}
+
+func restoreBundlesFromConfigInParallel(
+	repoList *RepoList,
+	ignoreRepoList []Repo,
+	concurrencyLevel int,
+	bundleDir string,
+) {
+	throttle := make(chan int, concurrencyLevel)
+
+	var wait sync.WaitGroup
+
+	for i := 0; i < len(*repoList); i++ {
+		throttle <- 1
+		wait.Add(1)
+
+		go func(repository *Repo, iwait *sync.WaitGroup, ithrottle chan int) {
+			defer iwait.Done()
+
+			if !ignoreThisRepo(repository.URL, ignoreRepoList) {
+				// bundle file is found by Gitfile path, before it is made absolute
+				bundleFile := repository.bundleFile(bundleDir)
+				repository.PrepareForGet()
+				log.Debugf("%s: process repo: '%s'", repository.sha, repository.URL)
+				repository.RestoreBundle(bundleFile)
+				repository.status.Processed = true
+			}
+
+			<-ithrottle
+		}(&(*repoList)[i], &wait, throttle)
+	}
+
+	wait.Wait()
+}
+
+// CreateBundles - Entry point for bundle creation logic, writes bundle with all refs of every
+// repository specified by Gitfile and manifest to the bundle directory, returns false if any
+// repository failed to bundle
+func CreateBundles(
+	cfgFiles []string,
+	ignoreFiles []string,
+	concurrencyLevel int,
+	defaultTrunkBranch string,
+	httpsAuth bool,
+	bundleDir string,
+) bool {
+	initColors()
+	defaultMainBranch = defaultTrunkBranch
+	httpsTokenAuth = httpsAuth
+
+	bundleDir, err := filepath.Abs(bundleDir)
+	if err == nil {
+		err = os.MkdirAll(bundleDir, os.ModePerm)
+	}
+	if err != nil {
+		log.Fatalf("Error: %s, while creating bundle directory", err)
+		os.Exit(1)
+	}
+
+	repoList := GetConfigRepoList(cfgFiles)
+	log.Debugf("Total number of repositories to bundle: '%d'", len(*repoList))
+
+	ignoreRepoList := GetIgnoreRepoList(ignoreFiles)
+	log.Debugf("Total number of repositories to ignore: '%d'", len(ignoreRepoList))
+
+	checkExportTargets(repoList, ignoreRepoList, bundleDir, bundleExtension)
+	createBundlesFromConfigInParallel(repoList, ignoreRepoList, concurrencyLevel, bundleDir)
+
+	writeExportManifest(repoList, bundleDir, bundleExtension)
+
+	return reportFailedRepos(repoList, "bundled")
+}
+
+// RestoreBundles - Entry point for bundle restore logic, clones repositories specified by Gitfile
+// from bundles in the bundle directory without network access, returns false if any repository
+// failed to restore
+func RestoreBundles(
+	cfgFiles []string,
+	ignoreFiles []string,
+	concurrencyLevel int,
+	defaultTrunkBranch string,
+	bundleDir string,
+) bool {
+	initColors()
+	defaultMainBranch = defaultTrunkBranch
+
+	bundleDir, err := filepath.Abs(bundleDir)
+	if err != nil {
+		log.Fatalf("Error: %s, while reading bundle directory", err)
+		os.Exit(1)
+	}
+
+	repoList := GetConfigRepoList(cfgFiles)
+	log.Debugf("Total number of repositories to restore: '%d'", len(*repoList))
+
+	ignoreRepoList := GetIgnoreRepoList(ignoreFiles)
+	log.Debugf("Total number of repositories to ignore: '%d'", len(ignoreRepoList))
+
+	restoreBundlesFromConfigInParallel(repoList, ignoreRepoList, concurrencyLevel, bundleDir)
+
+	return reportFailedRepos(repoList, "restored")
+}
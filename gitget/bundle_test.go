@@ -0,0 +1,94 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Repo_bundleFile(t *testing.T) {
+	repo := Repo{URL: "git@github.com:acme/api.git", Path: "deps"}
+	assert.Equal(t, filepath.Join("/bundles", "deps", "api.bundle"), repo.bundleFile("/bundles"))
+
+	repo = Repo{URL: "git@github.com:acme/api.git", AltName: "alt"}
+	assert.Equal(t, filepath.Join("/bundles", "alt.bundle"), repo.bundleFile("/bundles"))
+}
+
+func Test_conflictingExportTargets_Bundle(t *testing.T) {
+	repoList := RepoList{
+		{URL: "git@github.com:acme/api.git", Path: "deps"},
+		{URL: "git@gitlab.com:acme/api.git", Path: "deps"},
+		{URL: "git@github.com:acme/web.git", Path: "deps", AltName: "web-app"},
+	}
+
+	assert.Equal(t, []string{
+		"'/bundles/deps/api.bundle' is used by more than one repository",
+	}, conflictingExportTargets(&repoList, nil, "/bundles", bundleExtension))
+}
+
+func Test_Repo_CreateBundle_RestoreBundle(t *testing.T) {
+	sourceDir, commit := exportSource(t)
+	gitRun(t, sourceDir, "branch", "feature")
+	gitRun(t, sourceDir, "tag", "v1")
+	bundleDir := t.TempDir()
+	sourceURL := "file://" + sourceDir
+
+	repo := Repo{URL: sourceURL, Ref: "main", AltName: "source", Path: bundleDir}
+	repo.SetShellRunner(shellRunner)
+	repo.SetRepoFullPath()
+	repo.SetSha()
+
+	assert.True(t, repo.CreateBundle(t.TempDir()))
+	assert.Equal(t, commit, repo.commit)
+	assert.Equal(t, filepath.Join(bundleDir, "source"), repo.fullPath)
+	bundleFile := filepath.Join(bundleDir, "source.bundle")
+	assert.FileExists(t, bundleFile)
+
+	// bundle is created when ref doesn't exist, its commit is not recorded
+	unresolved := Repo{URL: sourceURL, Ref: "missing", AltName: "unresolved", Path: bundleDir}
+	unresolved.SetShellRunner(shellRunner)
+	unresolved.SetRepoFullPath()
+	assert.True(t, unresolved.CreateBundle(t.TempDir()))
+	assert.Empty(t, unresolved.commit)
+	assert.False(t, unresolved.status.Error)
+	assert.FileExists(t, filepath.Join(bundleDir, "unresolved.bundle"))
+
+	var outb bytes.Buffer
+	_, err := shellRunner.ExecGitCommand([]string{"bundle", "list-heads", bundleFile}, &outb, nil, "")
+	require.NoError(t, err)
+	assert.Contains(t, outb.String(), "refs/heads/feature")
+	assert.Contains(t, outb.String(), "refs/tags/v1")
+
+	cloneDir := t.TempDir()
+	restored := Repo{URL: sourceURL, Ref: "main", AltName: "source", Path: cloneDir}
+	restored.SetShellRunner(shellRunner)
+	restored.SetRepoFullPath()
+	assert.True(t, restored.RestoreBundle(bundleFile))
+	assert.FileExists(t, filepath.Join(cloneDir, "source", "a", "file"))
+
+	outb.Reset()
+	_, err = shellRunner.ExecGitCommand([]string{"remote", "get-url", "origin"}, &outb, nil, restored.fullPath)
+	require.NoError(t, err)
+	assert.Equal(t, sourceURL, strings.TrimSpace(outb.String()))
+
+	outb.Reset()
+	_, err = shellRunner.ExecGitCommand([]string{"rev-parse", "origin/feature", "v1"}, &outb, nil, restored.fullPath)
+	require.NoError(t, err)
+	assert.Equal(t, commit+"\n"+commit, strings.TrimSpace(outb.String()))
+
+	// existing clone is not changed
+	assert.True(t, restored.RestoreBundle(filepath.Join(bundleDir, "missing.bundle")))
+
+	missing := Repo{URL: sourceURL, Ref: "main", AltName: "missing", Path: cloneDir}
+	missing.SetShellRunner(shellRunner)
+	missing.SetRepoFullPath()
+	assert.False(t, missing.RestoreBundle(filepath.Join(bundleDir, "missing.bundle")))
+	assert.True(t, missing.status.Error)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// mirrorStateFile - file in the cached bare repository recording push arguments and refs of the last successful push
+const mirrorStateFile = "git-get-mirror-state"
+
+// cacheLocks - serialises operations on the same cached repository, when the same source
+// repository is listed in configuration more than once
+var cacheLocks sync.Map
+
+// SetMirrorCachePath - sets repository path in the mirror cache, which is derived from source
+// repository URL, so that it stays the same between runs ( <cache>/gitlab.com/src/a/repo.git )
+func (repo *Repo) SetMirrorCachePath(cacheDir string) {
+	repo.fullPath = repo.cachePath(cacheDir)
+}
+
+// cachePath - returns path of the repository bare clone in the cache directory
+func (repo *Repo) cachePath(cacheDir string) string {
+	host, fullName, _ := DecomposeGitURL(repo.URL)
+
+	return filepath.Join(cacheDir, host, filepath.FromSlash(fullName)+".git")
+}
+
+// lockMirrorCache - locks cached repository and returns function releasing the lock
+func (repo *Repo) lockMirrorCache() func() {
+	lock, _ := cacheLocks.LoadOrStore(repo.fullPath, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+
+	return lock.(*sync.Mutex).Unlock
+}
+
+// mirrorRefs - returns all refs of the bare repository with the objects they point to
+func (repo *Repo) mirrorRefs() (string, error) {
+	var outb, errb bytes.Buffer
+	_, err := (*repo.executor).ExecGitCommand(
+		[]string{"for-each-ref", "--format=%(objectname) %(refname)"},
+		&outb,
+		&errb,
+		repo.fullPath,
+	)
+	if err != nil {
+		log.Errorf("%s: %v %v", repo.sha, err, errb.String())
+		return "", err
+	}
+
+	return outb.String(), nil
+}
+
+// FsckMirrorCache - checks integrity of the cached repository, returns false if it is corrupted
+func (repo *Repo) FsckMirrorCache() bool {
+	log.Infof("%s: Checking integrity of cached mirror '%s'", repo.sha, repo.fullPath)
+	var serr bytes.Buffer
+	_, err := (*repo.executor).ExecGitCommand(
+		[]string{"fsck", "--no-progress"},
+		nil,
+		&serr,
+		repo.fullPath,
+	)
+	if err != nil {
+		log.Warnf("%s: Cached mirror '%s' is corrupted: %v %v", repo.sha, repo.fullPath, err, serr.String())
+		return false
+	}
+
+	return true
+}
+
+// UpdateMirror - fetches changes of the source repository into the cached repository, pruning
+// refs deleted in the source
+func (repo *Repo) UpdateMirror() bool {
+	log.Infof("%s: Update cached mirror of repository '%s'", repo.sha, repo.URL)
+	var serr bytes.Buffer
+	// source URL may have changed scheme between runs, e.g. switched from ssh to https
+	_, err := (*repo.executor).ExecGitCommand(
+		[]string{"remote", "set-url", "origin", repo.URL},
+		nil,
+		&serr,
+		repo.fullPath,
+	)
+	if err == nil {
+		_, err = (*repo.executor).ExecGitCommand(
+			[]string{"remote", "update", "--prune"},
+			nil,
+			&serr,
+			repo.fullPath,
+		)
+	}
+	if err != nil {
+		repo.mirrorFailed(err, serr.String())
+		log.Errorf("%s: %v %v", repo.sha, err, serr.String())
+		return false
+	}
+
+	return true
+}
+
+// SyncMirrorCache - creates or updates cached repository, corrupted cache is cloned again
+// when integrity check is requested
+func (repo *Repo) SyncMirrorCache(fsck bool) bool {
+	exists, _ := PathExists(repo.fullPath)
+	if exists && fsck && !repo.FsckMirrorCache() {
+		log.Warnf("%s: Removing corrupted cached mirror '%s'", repo.sha, repo.fullPath)
+		if err := os.RemoveAll(repo.fullPath); err != nil {
+			repo.mirrorFailed(err, "")
+			log.Errorf("%s: %v", repo.sha, err)
+			return false
+		}
+		exists = false
+	}
+
+	if exists {
+		return repo.UpdateMirror()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(repo.fullPath), 0o755); err != nil {
+		repo.mirrorFailed(err, "")
+		log.Errorf("%s: %v", repo.sha, err)
+		return false
+	}
+
+	return repo.CloneMirror(repo.fullPath)
+}
+
+// mirrorState - returns state of the cached repository to compare with the last pushed state, push
+// arguments include mirror URL and refspecs, so changed ref selection requires push as well
+func (repo *Repo) mirrorState() (string, error) {
+	refs, err := repo.mirrorRefs()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(repo.mirrorPushArgs(), " ") + "\n" + refs, nil
+}
+
+// MirrorChanged - returns true if refs of the cached repository differ from refs pushed to
+// the mirror by the last successful push
+func (repo *Repo) MirrorChanged() bool {
+	state, err := repo.mirrorState()
+	if err != nil {
+		return true
+	}
+
+	pushedState, err := os.ReadFile(filepath.Join(repo.fullPath, mirrorStateFile))
+	if err != nil {
+		return true
+	}
+
+	return state != string(pushedState)
+}
+
+// SaveMirrorState - records refs pushed to the mirror
+func (repo *Repo) SaveMirrorState() {
+	state, err := repo.mirrorState()
+	if err == nil {
+		err = os.WriteFile(filepath.Join(repo.fullPath, mirrorStateFile), []byte(state), 0o644)
+	}
+	if err != nil {
+		log.Warnf("%s: Unable to record mirror state, mirror will be pushed on the next run: %v", repo.sha, err)
+	}
+}
+
+// mirrorFromCache - updates cached repository and pushes it to the mirror if refs changed
+// since the last successful push
+func (repo *Repo) mirrorFromCache(pushMirror, fsck bool) {
+	unlock := repo.lockMirrorCache()
+	defer unlock()
+
+	if !repo.SyncMirrorCache(fsck) {
+		return
+	}
+
+	if !pushMirror {
+		log.Infof("%s: skipping '%s' remote push per user request", repo.sha, repo.URL)
+		return
+	}
+
+	if !repo.MirrorChanged() {
+		log.Infof("%s: mirror '%s' is up to date, skipping push", repo.sha, repo.mirrorURL)
+		return
+	}
+
+	repo.EnsureMirrorExists()
+	// failed LFS mirroring is retried on the next run
+	lfsMirrored := repo.MirrorLFS()
+	if !repo.PushMirror() || !lfsMirrored {
+		return
+	}
+	// refs rejected by mirror are pushed again on the next run, hidden refs are never accepted and
+	// should be excluded from mirroring to keep mirror cache state
+	if len(repo.rejectedRefs) > 0 {
+		log.Warnf("%s: mirror '%s' rejected refs, push will be retried on the next run", repo.sha, repo.mirrorURL)
+		return
+	}
+	repo.SaveMirrorState()
+}
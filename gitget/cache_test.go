@@ -0,0 +1,96 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isindir/git-get/exec"
+)
+
+// gitRun - runs git command in the directory failing the test on error
+func gitRun(t *testing.T, dir string, args ...string) {
+	runner := &exec.ShellRunner{Env: []string{
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	}}
+	_, err := runner.ExecGitCommand(args, nil, nil, dir)
+	require.NoError(t, err, "git %v", args)
+}
+
+// initSourceRepo - creates repository in the directory on `main` branch with the first commit of
+// `files` ( path -> content ), commit is empty if there are no files
+func initSourceRepo(t *testing.T, dir string, files map[string]string) {
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	gitRun(t, dir, "init", "-q", "-b", "main")
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "--allow-empty", "-m", "first")
+}
+
+func Test_SetMirrorCachePath(t *testing.T) {
+	repo := Repo{URL: "ssh://git@bitbucket.acme.com:7999/src/a/repo.git"}
+	repo.SetMirrorCachePath("/cache")
+	assert.Equal(t, filepath.Join("/cache", "bitbucket.acme.com", "src", "a", "repo.git"), repo.fullPath)
+}
+
+func Test_Repo_MirrorCache(t *testing.T) {
+	sourceDir := filepath.Join(t.TempDir(), "source")
+	initSourceRepo(t, sourceDir, nil)
+
+	repo := Repo{URL: sourceDir, mirrorURL: "git@gitlab.com:acme/mirrors/source.git"}
+	repo.SetShellRunner(shellRunner)
+	repo.fullPath = filepath.Join(t.TempDir(), "cache", "source.git")
+
+	// first run clones repository into cache, it was never pushed
+	assert.True(t, repo.SyncMirrorCache(false))
+	assert.True(t, repo.MirrorChanged())
+	repo.SaveMirrorState()
+	assert.False(t, repo.MirrorChanged())
+
+	// update without changes in source keeps pushed state
+	assert.True(t, repo.SyncMirrorCache(true))
+	assert.False(t, repo.MirrorChanged())
+
+	// new branch in source is fetched, deleted branch is pruned
+	gitRun(t, sourceDir, "branch", "feature")
+	assert.True(t, repo.SyncMirrorCache(false))
+	assert.True(t, repo.MirrorChanged())
+	repo.SaveMirrorState()
+	gitRun(t, sourceDir, "branch", "-D", "feature")
+	assert.True(t, repo.SyncMirrorCache(false))
+	assert.True(t, repo.MirrorChanged())
+	refs, err := repo.mirrorRefs()
+	assert.NoError(t, err)
+	assert.NotContains(t, refs, "refs/heads/feature")
+	repo.SaveMirrorState()
+
+	// changed ref selection requires push
+	repo.MirrorRefs = []string{"refs/heads/*"}
+	assert.True(t, repo.MirrorChanged())
+	repo.SaveMirrorState()
+	assert.False(t, repo.MirrorChanged())
+	repo.MirrorExcludeRefs = []string{"refs/heads/wip/*"}
+	assert.True(t, repo.MirrorChanged())
+	repo.SaveMirrorState()
+
+	// pushing to a different mirror requires push
+	repo.mirrorURL = "git@gitlab.com:acme/other/source.git"
+	assert.True(t, repo.MirrorChanged())
+
+	// corrupted cache is cloned again when integrity check is requested
+	require.NoError(t, os.RemoveAll(filepath.Join(repo.fullPath, "objects")))
+	require.NoError(t, os.MkdirAll(filepath.Join(repo.fullPath, "objects"), 0o755))
+	assert.False(t, repo.FsckMirrorCache())
+	assert.True(t, repo.SyncMirrorCache(true))
+	assert.True(t, repo.FsckMirrorCache())
+}
@@ -0,0 +1,332 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Export formats
+const (
+	ExportFormatTar   = "tar"
+	ExportFormatTarGz = "tar.gz"
+	ExportFormatZip   = "zip"
+	ExportFormatTree  = "tree" // repositories files are written to vendored directory tree
+)
+
+// exportManifestFile - name of the manifest file written to export output directory
+const exportManifestFile = "manifest.yaml"
+
+// ExportManifestEntry - exported repository record of the export manifest
+type ExportManifestEntry struct {
+	URL     string `yaml:"url"`
+	Path    string `yaml:"path,omitempty"` // path relative to export output directory
+	AltName string `yaml:"altname"`
+	Ref     string `yaml:"ref"`
+	Commit  string `yaml:"commit,omitempty"` // commit SHA the ref pointed to, empty if bundled ref was not found
+	File    string `yaml:"file"`             // archive file or directory relative to export output directory
+}
+
+// validateExportFormat - ensures export format is known
+func validateExportFormat(format string) {
+	switch format {
+	case ExportFormatTar, ExportFormatTarGz, ExportFormatZip, ExportFormatTree:
+	default:
+		log.Fatalf("Error: unknown '%s' export format", format)
+		os.Exit(1)
+	}
+}
+
+// PrepareForExport - sets repository export path below output directory, same as repository clone
+// path is set below current directory
+func (repo *Repo) PrepareForExport(outputDir string) {
+	repo.SetShellRunner(shellRunner)
+	repo.EnsurePathExists(outputDir)
+	repo.SetDefaultRef()
+	repo.SetRepoLocalName()
+	repo.SetRepoFullPath()
+	repo.SetSha()
+	repo.SetHTTPSTokenAuth()
+
+	log.Infof("%s: url: %s (%s) -> %s", repo.sha, repo.URL, colorRef.Sprintf("%s", repo.Ref), repo.fullPath)
+}
+
+// exportTarget - returns archive file or directory the repository is exported to
+func (repo *Repo) exportTarget(format string) string {
+	if format == ExportFormatTree {
+		return repo.fullPath
+	}
+
+	return repo.fullPath + "." + format
+}
+
+// exportGitCommand - runs git command in the bare clone of the exported repository
+func (repo *Repo) exportGitCommand(args []string, outb *bytes.Buffer, dir string) bool {
+	var serr bytes.Buffer
+	_, err := (*repo.executor).ExecGitCommand(args, outb, &serr, dir)
+	if err != nil {
+		repo.status.Error = true
+		repo.status.OperationErrorMessage = strings.TrimSpace(serr.String())
+		log.Errorf("%s: %v %v", repo.sha, err, serr.String())
+		return false
+	}
+
+	return true
+}
+
+// Export writes files of the repository at its ref to archive or directory tree without a working
+// clone, only the ref commit is fetched to a temporary bare clone, `sparse` paths limit exported
+// files, submodules are not exported
+func (repo *Repo) Export(workDir, format string) bool {
+	log.Infof("%s: Export repository '%s'", repo.sha, repo.URL)
+	bareClonePath := filepath.Join(workDir, repo.sha+".git")
+	defer os.RemoveAll(bareClonePath)
+
+	cloneArgs := []string{"clone", "--bare", "--depth", "1"}
+	if repo.Filter != "" {
+		cloneArgs = append(cloneArgs, "--filter="+repo.Filter)
+	}
+	cloneArgs = append(cloneArgs, "--branch", repo.Ref, repo.URL, bareClonePath)
+	if !repo.exportGitCommand(cloneArgs, nil, "") {
+		return false
+	}
+
+	var outb bytes.Buffer
+	if !repo.exportGitCommand([]string{"rev-parse", "HEAD^{commit}"}, &outb, bareClonePath) {
+		return false
+	}
+	repo.commit = strings.TrimSpace(outb.String())
+
+	target := repo.exportTarget(format)
+	if err := os.RemoveAll(target); err != nil {
+		log.Fatalln(err)
+		os.Exit(1)
+	}
+
+	var args []string
+	if format == ExportFormatTree {
+		if err := os.MkdirAll(target, os.ModePerm); err != nil {
+			log.Fatalln(err)
+			os.Exit(1)
+		}
+		args = []string{"--work-tree", target, "checkout", "--force", "HEAD", "--"}
+		if len(repo.Sparse) == 0 {
+			args = append(args, ".")
+		}
+	} else {
+		args = []string{"archive", "--format", format, "--prefix", repo.AltName + "/", "--output", target, "HEAD", "--"}
+	}
+
+	return repo.exportGitCommand(append(args, repo.Sparse...), nil, bareClonePath)
+}
+
+// conflictingExportTargets - returns export targets shared by more than one repository and tree export
+// targets nested in target of another repository, as repositories are exported concurrently and every
+// target is removed before export, such targets would overwrite or remove each other's files
+// ( deps/api contains deps/api/vendor/lib )
+func conflictingExportTargets(repoList *RepoList, ignoreRepoList []Repo, outputDir, format string) []string {
+	var targets []string
+	for _, repo := range *repoList {
+		if ignoreThisRepo(repo.URL, ignoreRepoList) {
+			continue
+		}
+		target := path.Join(outputDir, repo.Path, repo.GetRepoLocalName())
+		if format != ExportFormatTree {
+			target += "." + format
+		}
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	var conflicts []string
+	for i := range targets {
+		for j := i + 1; j < len(targets); j++ {
+			if targets[j] == targets[i] {
+				conflicts = append(conflicts, fmt.Sprintf("'%s' is used by more than one repository", targets[i]))
+			} else if format == ExportFormatTree && strings.HasPrefix(targets[j], targets[i]+"/") {
+				conflicts = append(conflicts, fmt.Sprintf("'%s' contains '%s'", targets[i], targets[j]))
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// checkExportTargets - fails before any repository is exported if export targets conflict
+func checkExportTargets(repoList *RepoList, ignoreRepoList []Repo, outputDir, format string) {
+	conflicts := conflictingExportTargets(repoList, ignoreRepoList, outputDir, format)
+	if len(conflicts) == 0 {
+		return
+	}
+
+	for _, message := range conflicts {
+		log.Errorf("Export target %s", message)
+	}
+	log.Fatalf(
+		"Error: %d conflicting '%s' export target(s) found, use different 'path' or 'altname' in configuration",
+		len(conflicts), format)
+	os.Exit(1)
+}
+
+func exportReposFromConfigInParallel(
+	repoList *RepoList,
+	ignoreRepoList []Repo,
+	concurrencyLevel int,
+	outputDir string,
+	format string,
+) {
+	throttle := make(chan int, concurrencyLevel)
+
+	var wait sync.WaitGroup
+
+	workDir, err := os.MkdirTemp("", "gitgetexport")
+	if err != nil {
+		log.Fatalf("Error: %s, while creating temporary directory", err)
+		os.Exit(1)
+	}
+	defer os.RemoveAll(workDir)
+
+	for i := 0; i < len(*repoList); i++ {
+		throttle <- 1
+		wait.Add(1)
+
+		go func(repository *Repo, iwait *sync.WaitGroup, ithrottle chan int) {
+			defer iwait.Done()
+
+			if !ignoreThisRepo(repository.URL, ignoreRepoList) {
+				repository.PrepareForExport(outputDir)
+				log.Debugf("%s: process repo: '%s'", repository.sha, repository.URL)
+				repository.Export(workDir, format)
+				repository.status.Processed = true
+			}
+
+			<-ithrottle
+		}(&(*repoList)[i], &wait, throttle)
+	}
+
+	wait.Wait()
+}
+
+// exportManifest - returns manifest entries of successfully exported repositories
+func exportManifest(repoList *RepoList, outputDir, format string) []ExportManifestEntry {
+	var manifest []ExportManifestEntry
+	for _, repo := range *repoList {
+		if !repo.status.Processed || repo.status.Error {
+			continue
+		}
+		repoPath, _ := filepath.Rel(outputDir, repo.Path)
+		if repoPath == "." {
+			repoPath = ""
+		}
+		file, _ := filepath.Rel(outputDir, repo.exportTarget(format))
+		manifest = append(manifest, ExportManifestEntry{
+			URL:     repo.URL,
+			Path:    repoPath,
+			AltName: repo.AltName,
+			Ref:     repo.Ref,
+			Commit:  repo.commit,
+			File:    file,
+		})
+	}
+
+	return manifest
+}
+
+// writeExportManifest - writes manifest of successfully exported repositories to output directory
+func writeExportManifest(repoList *RepoList, outputDir, format string) {
+	manifestFile := filepath.Join(outputDir, exportManifestFile)
+	manifestData, err := yaml.Marshal(exportManifest(repoList, outputDir, format))
+	if err != nil {
+		log.Fatalf("%s: %s", manifestFile, err)
+		os.Exit(1)
+	}
+	log.Infof("Writing manifest '%s'", manifestFile)
+	if err := os.WriteFile(manifestFile, manifestData, 0o644); err != nil {
+		log.Fatalf("%s: %s", manifestFile, err)
+		os.Exit(1)
+	}
+}
+
+// reportFailedRepos - logs repositories which operation failed, returns false if there are any
+func reportFailedRepos(repoList *RepoList, operation string) bool {
+	failed := 0
+	for _, repo := range *repoList {
+		if repo.status.Error {
+			log.Errorf("Repository '%s' was not %s: %s", repo.URL, operation, repo.status.OperationErrorMessage)
+			failed++
+		}
+	}
+
+	return failed == 0
+}
+
+// ExportRepositories - Entry point for export logic, writes repositories specified by Gitfile at
+// their refs to archives or vendored directory tree and manifest to the output directory, returns
+// false if any repository failed to export
+func ExportRepositories(
+	cfgFiles []string,
+	ignoreFiles []string,
+	concurrencyLevel int,
+	defaultTrunkBranch string,
+	httpsAuth bool,
+	outputDir string,
+	format string,
+) bool {
+	initColors()
+	defaultMainBranch = defaultTrunkBranch
+	httpsTokenAuth = httpsAuth
+	validateExportFormat(format)
+
+	outputDir, err := filepath.Abs(outputDir)
+	if err == nil {
+		err = os.MkdirAll(outputDir, os.ModePerm)
+	}
+	if err != nil {
+		log.Fatalf("Error: %s, while creating export output directory", err)
+		os.Exit(1)
+	}
+
+	repoList := GetConfigRepoList(cfgFiles)
+	log.Debugf("Total number of repositories to export: '%d'", len(*repoList))
+	validateCloneFilters(repoList)
+
+	ignoreRepoList := GetIgnoreRepoList(ignoreFiles)
+	log.Debugf("Total number of repositories to ignore: '%d'", len(ignoreRepoList))
+
+	checkExportTargets(repoList, ignoreRepoList, outputDir, format)
+	exportReposFromConfigInParallel(repoList, ignoreRepoList, concurrencyLevel, outputDir, format)
+
+	writeExportManifest(repoList, outputDir, format)
+
+	return reportFailedRepos(repoList, "exported")
+}
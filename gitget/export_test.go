@@ -0,0 +1,99 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"archive/zip"
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportSource - creates repository with files in `a` and `b` directories, returns its path and commit
+func exportSource(t *testing.T) (string, string) {
+	sourceDir := filepath.Join(t.TempDir(), "source")
+	initSourceRepo(t, sourceDir, map[string]string{"a/file": "a", "b/file": "b"})
+
+	var outb bytes.Buffer
+	_, err := shellRunner.ExecGitCommand([]string{"rev-parse", "HEAD"}, &outb, nil, sourceDir)
+	require.NoError(t, err)
+
+	return sourceDir, strings.TrimSpace(outb.String())
+}
+
+func Test_Repo_Export(t *testing.T) {
+	sourceDir, commit := exportSource(t)
+	outputDir := t.TempDir()
+
+	repo := Repo{URL: "file://" + sourceDir, Ref: "main", AltName: "source", Path: outputDir}
+	repo.SetShellRunner(shellRunner)
+	repo.SetRepoFullPath()
+
+	assert.True(t, repo.Export(t.TempDir(), ExportFormatTree))
+	assert.Equal(t, commit, repo.commit)
+	assert.FileExists(t, filepath.Join(outputDir, "source", "a", "file"))
+	assert.FileExists(t, filepath.Join(outputDir, "source", "b", "file"))
+	assert.NoDirExists(t, filepath.Join(outputDir, "source", ".git"))
+
+	repo.Sparse = []string{"b"}
+	assert.True(t, repo.Export(t.TempDir(), ExportFormatZip))
+	archive, err := zip.OpenReader(filepath.Join(outputDir, "source.zip"))
+	require.NoError(t, err)
+	defer archive.Close()
+	var files []string
+	for _, file := range archive.File {
+		files = append(files, file.Name)
+	}
+	assert.Equal(t, []string{"source/", "source/b/", "source/b/file"}, files)
+
+	repo.Ref = "missing"
+	assert.False(t, repo.Export(t.TempDir(), ExportFormatTarGz))
+	assert.True(t, repo.status.Error)
+}
+
+func Test_exportManifest(t *testing.T) {
+	repoList := RepoList{
+		{URL: "git@github.com:acme/api.git", Path: "/out/deps", AltName: "api", Ref: "main", commit: "abc", status: RepoStatus{Processed: true}},
+		{URL: "git@github.com:acme/web.git", Path: "/out", AltName: "web", Ref: "v1", commit: "def", status: RepoStatus{Processed: true}},
+		{URL: "git@github.com:acme/failed.git", Path: "/out", AltName: "failed", status: RepoStatus{Processed: true, Error: true}},
+		{URL: "git@github.com:acme/ignored.git"},
+	}
+	for i := range repoList {
+		repoList[i].SetRepoFullPath()
+	}
+
+	assert.Equal(t, []ExportManifestEntry{
+		{URL: "git@github.com:acme/api.git", Path: "deps", AltName: "api", Ref: "main", Commit: "abc", File: "deps/api.tar.gz"},
+		{URL: "git@github.com:acme/web.git", AltName: "web", Ref: "v1", Commit: "def", File: "web.tar.gz"},
+	}, exportManifest(&repoList, "/out", ExportFormatTarGz))
+}
+
+func Test_conflictingExportTargets(t *testing.T) {
+	repoList := RepoList{
+		{URL: "git@github.com:acme/api.git", Path: "deps"},
+		{URL: "git@github.com:acme/lib.git", Path: "deps/api/vendor"},
+		{URL: "git@github.com:acme/web.git", Path: "deps", AltName: "api"},
+		{URL: "git@github.com:acme/apis.git", Path: "deps"},
+		{URL: "git@github.com:acme/ignored.git", Path: "deps/apis"},
+	}
+	ignoreRepoList := []Repo{{URL: "git@github.com:acme/ignored.git"}}
+
+	assert.Equal(t, []string{
+		"'/out/deps/api' is used by more than one repository",
+		"'/out/deps/api' contains '/out/deps/api/vendor/lib'",
+		"'/out/deps/api' contains '/out/deps/api/vendor/lib'",
+	}, conflictingExportTargets(&repoList, ignoreRepoList, "/out", ExportFormatTree))
+
+	// archives can't be nested, but repositories with the same path and name share the archive
+	assert.Equal(t, []string{
+		"'/out/deps/api.zip' is used by more than one repository",
+	}, conflictingExportTargets(&repoList, ignoreRepoList, "/out", ExportFormatZip))
+
+	assert.Empty(t, conflictingExportTargets(&repoList, append(ignoreRepoList, repoList[1:3]...), "/out", ExportFormatTree))
+	assert.Empty(t, conflictingExportTargets(&repoList, append(ignoreRepoList, repoList[2]), "/out", ExportFormatTarGz))
+}
@@ -0,0 +1,59 @@
+//go:build windows
+// +build windows
+
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile - takes exclusive lock of the file, waiting until it is released by other holders
+func lockFile(file *os.File) error {
+	return windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, &windows.Overlapped{})
+}
+
+// shareFileLock - converts lock of the file to shared lock, so other holders can share it, Windows
+// can't convert locks, so the exclusive lock is released first and taken again if shared lock fails
+func shareFileLock(file *os.File) error {
+	if err := unlockFile(file); err != nil {
+		return err
+	}
+
+	err := windows.LockFileEx(windows.Handle(file.Fd()), 0, 0, 1, 0, &windows.Overlapped{})
+	if err != nil {
+		if lockErr := lockFile(file); lockErr != nil {
+			return lockErr
+		}
+	}
+
+	return err
+}
+
+// unlockFile - releases lock of the file
+func unlockFile(file *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, &windows.Overlapped{})
+}
@@ -36,15 +36,18 @@ import (
 	"strings"
 	"sync"
 	"text/tabwriter"
+	"text/template"
+	"time"
 
 	"github.com/fatih/color"
+	// UPDATE_HERE
+	gh "github.com/google/go-github/v81/github"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 
-	"github.com/isindir/git-get/bitbucket"
+	"github.com/isindir/git-get/bitbucketserver"
 	"github.com/isindir/git-get/exec"
 	"github.com/isindir/git-get/github"
-	"github.com/isindir/git-get/gitlab"
 )
 
 const (
@@ -53,14 +56,32 @@ const (
 )
 
 var (
-	stayOnRef              bool
-	defaultMainBranch      = "master"
-	gitProvider            string
-	mirrorVisibilityMode   = "private"
-	bitbucketMirrorProject = ""
-	colorHighlight         *color.Color
-	colorRef               *color.Color
-	shellRunner            = new(exec.ShellRunner)
+	stayOnRef                  bool
+	defaultMainBranch          = "master"
+	gitProvider                string
+	mirrorVisibilityMode       = "private"
+	bitbucketMirrorProject     = ""
+	bitbucketServerURL         = ""
+	githubCABundle             = ""
+	githubMirrorTeam           = ""
+	githubMirrorTeamPermission = "push"
+	gitlabMirrorNamespaceID    int64
+	httpsTokenAuth             = false
+	mirrorNaming               = MirrorNamingFlat
+	mirrorNameTemplate         *template.Template
+	mirrorCacheDir             = ""
+	mirrorCacheFsck            = false
+	mirrorRefs                 []string
+	mirrorExcludeRefs          []string
+	mirrorNoDelete             = false
+	mirrorMode                 = MirrorModePush
+	mirrorSyncMetadata         = false
+	submodulesMode             = SubmodulesNone
+	shallowKeepGit             = false
+	referenceCacheDir          = ""
+	colorHighlight             *color.Color
+	colorRef                   *color.Color
+	shellRunner                = new(exec.ShellRunner)
 )
 
 // ConfigGenParamsStruct - data structure to store parameters passed via cli flags
@@ -76,13 +97,15 @@ type ConfigGenParamsStruct struct {
 	// GitHub specific vars
 	GithubVisibility  string
 	GithubAffiliation string
+	GithubPathByOwner bool
+	GithubTeam        string
+	GithubCABundle    string
 
 	// Bitbucket specific vars
-	/*
-		MAYBE: implement for bitbucket to allow subset of repositories
-		BitbucketDivision string
-	*/
-	BitbucketRole string
+	BitbucketRole          string
+	BitbucketProjects      []string
+	BitbucketPathByProject bool
+	BitbucketServerURL     string
 }
 
 type bitbucketLinks struct {
@@ -97,12 +120,26 @@ type Repo struct {
 	AltName  string   `yaml:"altname,omitempty"`  // when cloned, repository will have different name from remote
 	Ref      string   `yaml:"ref,omitempty"`      // branch to clone (normally trunk branch name, but git sha or git tag can be also specified)
 	Symlinks []string `yaml:"symlinks,omitempty"` // paths where to create symlinks to the repository clone
+	// mirror push ref filtering, extends or overrides per run configuration:
+	MirrorRefs        []string `yaml:"mirror_refs,omitempty"`         // refs patterns to push instead of all refs (example: refs/heads/*)
+	MirrorExcludeRefs []string `yaml:"mirror_exclude_refs,omitempty"` // refs patterns never pushed (example: refs/pull/*)
+	MirrorNoDelete    bool     `yaml:"mirror_no_delete,omitempty"`    // do not delete mirror refs missing in source
+	LFS               bool     `yaml:"lfs,omitempty"`                 // mirror Git LFS objects, detected by .gitattributes if not set
+	Submodules        string   `yaml:"submodules,omitempty"`          // submodules mode [none|init|recursive], overrides per run one
+	Sparse            []string `yaml:"sparse,omitempty"`              // directories to check out with sparse checkout instead of all files
+	Filter            string   `yaml:"filter,omitempty"`              // partial clone filter (example: blob:none, tree:0)
 	// helper fields, not supposed to be written or read in Gitfile:
-	fullPath  string     `yaml:"full_path,omitempty"`
-	sha       string     `yaml:"sha,omitempty"`
-	mirrorURL string     `yaml:"mirror_url,omitempty"`
-	status    RepoStatus // keep track of the repository status after operation to provide summary
-	executor  *exec.ShellRunnerI
+	fullPath          string     `yaml:"full_path,omitempty"`
+	sha               string     `yaml:"sha,omitempty"`
+	mirrorURL         string     `yaml:"mirror_url,omitempty"`
+	rejectedRefs      []string   // mirror refs rejected by the last push
+	defaultRef        bool       // Ref is not configured and defaults to default main branch
+	commit            string     // commit SHA of the Ref written by export
+	referencePath     string     // reference cache repository objects are borrowed from during clone
+	submodulesUpdated bool       // `git submodule update` was run, submodules may have `.git` files
+	status            RepoStatus // keep track of the repository status after operation to provide summary
+	executor          *exec.ShellRunnerI
+	providers         *Providers // provider API clients shared by all repositories of the run
 }
 
 // RepoList is a slice of Repo structs
@@ -110,11 +147,17 @@ type RepoList []Repo
 
 // RepoStatus - data structure to track repository status
 type RepoStatus struct {
-	Processed             bool   // by default repository is not processed, and won't be if skipped
-	NotOnRefBranch        bool   // repository checked out branch is not trunk but feature branch
-	UncommittedChanges    bool   // there are no uncommitted or staged changes in the branch
-	OperationErrorMessage string // last operation error message if any
-	Error                 bool   // last operation error message if any
+	Processed              bool   // by default repository is not processed, and won't be if skipped
+	NotOnRefBranch         bool   // repository checked out branch is not trunk but feature branch
+	UncommittedChanges     bool   // there are no uncommitted or staged changes in the branch
+	OperationErrorMessage  string // last operation error message if any
+	Error                  bool   // last operation error message if any
+	LFSError               bool   // Git LFS objects were not mirrored
+	LFSErrorMessage        string // Git LFS operation error message if any
+	SubmodulesError        bool   // submodules were not initialised or updated
+	SubmodulesErrorMessage string // submodules update error message if any
+	Mirror                 MirrorStatus
+	Verify                 MirrorVerifyStatus
 }
 
 // RepoI interface defined for mocking purposes.
@@ -155,6 +198,7 @@ func initColors() {
 func (repo *Repo) SetDefaultRef() {
 	if repo.Ref == "" {
 		repo.Ref = defaultMainBranch
+		repo.defaultRef = true
 	}
 }
 
@@ -234,6 +278,7 @@ func (repo *Repo) PrepareForGet() {
 	repo.SetRepoLocalName()
 	repo.SetRepoFullPath()
 	repo.SetSha()
+	repo.SetHTTPSTokenAuth()
 
 	log.Infof("%s: url: %s (%s) -> %s", repo.sha, repo.URL, colorRef.Sprintf("%s", repo.Ref), repo.fullPath)
 	log.Debugf("%s: Repository structure: '%+v'", repo.sha, repo)
@@ -247,7 +292,11 @@ func (repo *Repo) PrepareForMirror(pathPrefix, mirrorRootURL string) {
 	repo.SetRepoLocalName()
 	repo.SetMirrorURL(mirrorRootURL)
 	repo.SetRepoFullPath()
+	if mirrorCacheDir != "" {
+		repo.SetMirrorCachePath(mirrorCacheDir)
+	}
 	repo.SetSha()
+	repo.SetHTTPSTokenAuth()
 
 	log.Infof("%s: url: %s (%s) -> %s", repo.sha, repo.URL, colorRef.Sprintf("%s", repo.Ref), repo.fullPath)
 	log.Debugf("%s: Repository structure: '%+v'", repo.sha, repo)
@@ -266,7 +315,7 @@ func (repo *Repo) GetRepoLocalName() string {
 }
 
 func (repo *Repo) SetMirrorURL(mirrorRootURL string) {
-	repo.mirrorURL = fmt.Sprintf("%s/%s.%s", mirrorRootURL, repo.AltName, "git")
+	repo.mirrorURL = fmt.Sprintf("%s/%s.%s", mirrorRootURL, repo.mirrorPath(), "git")
 }
 
 func (repo *Repo) SetRepoFullPath() {
@@ -288,35 +337,48 @@ func (repo *Repo) RepoPathExists() bool {
 	return res
 }
 
-// CloneMirror runs `git clone --mirror` command.
-func (repo *Repo) CloneMirror() bool {
+// CloneMirror runs `git clone --mirror` command cloning repository to `targetPath`.
+func (repo *Repo) CloneMirror(targetPath string) bool {
 	log.Infof("%s: Clone repository '%s' for mirror", repo.sha, repo.URL)
 	var serr bytes.Buffer
 	_, err := (*repo.executor).ExecGitCommand(
-		[]string{"clone", "--mirror", repo.URL, repo.fullPath},
+		[]string{"clone", "--mirror", repo.URL, targetPath},
 		nil,
 		&serr,
 		"",
 	)
 	if err != nil {
+		repo.mirrorFailed(err, serr.String())
 		log.Errorf("%s: %v %v", repo.sha, err, serr.String())
 		return false
 	}
 	return true
 }
 
-// PushMirror runs `git push --mirror` command.
+// PushMirror runs `git push --mirror` command or pushes selected refs, when ref filtering is configured,
+// refs rejected by mirror are reported individually, only rejected hidden refs don't fail the push
 func (repo *Repo) PushMirror() bool {
 	log.Infof("%s: Push repository '%s' as a mirror '%s'", repo.sha, repo.URL, repo.mirrorURL)
-	var serr bytes.Buffer
-	_, err := (*repo.executor).ExecGitCommand(
-		[]string{"push", "--mirror", repo.mirrorURL},
-		nil,
+	var outb, serr bytes.Buffer
+	// pushed bytes are parsed from git progress output, which is translated in other locales
+	_, err := exec.WithEnv(*repo.executor, "LC_ALL=C").ExecGitCommand(
+		repo.mirrorPushArgs(),
+		&outb,
 		&serr,
 		repo.fullPath,
 	)
-	if err != nil {
-		log.Errorf("%s: %v %v", repo.sha, err, serr.String())
+	repo.rejectedRefs = parsePushRejections(outb.String())
+	for _, rejectedRef := range repo.rejectedRefs {
+		log.Warnf("%s: mirror '%s' rejected %s", repo.sha, repo.mirrorURL, rejectedRef)
+	}
+	mirrorStatus := &repo.status.Mirror
+	mirrorStatus.RefsPushed, mirrorStatus.RefsUpdated, mirrorStatus.RefsDeleted = countPushedRefs(outb.String())
+	mirrorStatus.RefsRejected = len(repo.rejectedRefs)
+	mirrorStatus.Bytes = parsePushBytes(serr.String())
+	// push of other refs is not failed by hidden refs mirror rejects, e.g. Github pull request refs
+	if err != nil && !onlyHiddenRefsRejected(repo.rejectedRefs) {
+		repo.mirrorFailed(err, serr.String())
+		log.Errorf("%s: %v %v", repo.sha, err, stripGitProgress(serr.String()))
 		return false
 	}
 	return true
@@ -324,10 +386,13 @@ func (repo *Repo) PushMirror() bool {
 
 // Clone runs `git clone --branch` command.
 func (repo *Repo) Clone() bool {
+	unlock := repo.PrepareReferenceCache()
+	defer unlock()
+
 	log.Infof("%s: Clone repository '%s'", repo.sha, repo.URL)
 	var serr bytes.Buffer
 	_, err := (*repo.executor).ExecGitCommand(
-		[]string{"clone", "--branch", repo.Ref, repo.URL, repo.fullPath},
+		repo.cloneArgs(false),
 		nil,
 		&serr,
 		"",
@@ -337,15 +402,21 @@ func (repo *Repo) Clone() bool {
 		log.Errorf("%s: %v %v", repo.sha, err, serr.String())
 		return false
 	}
+	if len(repo.Sparse) > 0 {
+		return repo.SparseCheckout()
+	}
 	return true
 }
 
 // ShallowClone runs `git clone --depth 1 --branch` command.
 func (repo *Repo) ShallowClone() bool {
+	unlock := repo.PrepareReferenceCache()
+	defer unlock()
+
 	log.Infof("%s: Clone repository '%s'", repo.sha, repo.URL)
 	var serr bytes.Buffer
 	_, err := (*repo.executor).ExecGitCommand(
-		[]string{"clone", "--depth", "1", "--branch", repo.Ref, repo.URL, repo.fullPath},
+		repo.cloneArgs(true),
 		nil,
 		&serr,
 		"",
@@ -355,9 +426,40 @@ func (repo *Repo) ShallowClone() bool {
 		log.Errorf("%s: %v %v", repo.sha, err, serr.String())
 		return false
 	}
+	if len(repo.Sparse) > 0 {
+		return repo.SparseCheckout()
+	}
 	return true
 }
 
+// ShallowRefresh runs `git fetch --depth 1` of the ref from configured URL, `git reset --hard` to the
+// fetched commit and `git clean -ffdx`, so shallow clone kept with `.git` is updated without cloning
+// it again, local changes and untracked files are discarded
+func (repo *Repo) ShallowRefresh() bool {
+	log.Infof("%s: Refresh shallow clone of repository '%s'", repo.sha, repo.URL)
+	for _, args := range [][]string{
+		{"remote", "set-url", "origin", repo.URL},
+		{"fetch", "--depth", "1", "origin", repo.Ref},
+		{"reset", "--hard", "FETCH_HEAD"},
+		{"clean", "-ffdx"},
+	} {
+		var serr bytes.Buffer
+		_, err := (*repo.executor).ExecGitCommand(args, nil, &serr, repo.fullPath)
+		if err != nil {
+			repo.status.Error = true
+			log.Errorf("%s: %v %v", repo.sha, err, serr.String())
+			return false
+		}
+	}
+	return repo.SparseCheckout()
+}
+
+// GitDirExists - checks if repository path contains `.git`, shallow clones have it removed unless kept
+func (repo *Repo) GitDirExists() bool {
+	res, _ := PathExists(filepath.Join(repo.fullPath, ".git"))
+	return res
+}
+
 func (repo *Repo) RemoveTargetDir(dotGit bool) {
 	pathToRemove := ""
 	if dotGit {
@@ -594,12 +696,13 @@ func (repo *Repo) EnsureMirrorExists() {
 
 func (repo *Repo) EnsureGitlabMirrorExists() {
 	// ( a/b/c/d -> a , b , b/c/d, d )
-	baseURL, projectNameFullPath, projectNameShort := DecomposeGitURL(repo.mirrorURL)
+	baseURL, projectNameFullPath, projectPath := DecomposeGitURL(repo.mirrorURL)
+	// mirror URL contains project path, while project name keeps spaces and case of the mirror name
+	projectName := path.Base(repo.mirrorName())
 	log.Debugf("%s: For Check: BaseURL: %s projectNameFullPath: %s", repo.sha, baseURL, projectNameFullPath)
-	log.Debugf("%s: For Create: BaseURL: %s projectNameShort: %s", repo.sha, baseURL, projectNameShort)
+	log.Debugf("%s: For Create: BaseURL: %s projectName: %s projectPath: %s", repo.sha, baseURL, projectName, projectPath)
 	// In gitlab Project is both - repository and directory to aggregate repositories
-	gitlabObj := gitlab.GitGetGitlab{}
-	gitlabObj.Init()
+	gitlabObj := repo.providers.Gitlab
 
 	projectFound := gitlabObj.ProjectExists(repo.sha, baseURL, projectNameFullPath)
 
@@ -608,75 +711,146 @@ func (repo *Repo) EnsureGitlabMirrorExists() {
 		// identify if part `b` is a group ? then need to create project differently - potentially create all subgroups
 		projectNamespace, namespaceFullPath := gitlabObj.GetProjectNamespace(repo.sha, baseURL, projectNameFullPath)
 		log.Debugf("%s: '%s' is '%+v'", repo.sha, projectNameFullPath, projectNamespace)
-		// If project namespace exists and is user - create project for user
+		// If project namespace exists (user or group) - create project in it by namespace ID
 		// Otherwise ensure group with subgroups exists and create project in subgroup
+		namespaceID := int64(0)
 		if projectNamespace != nil {
-			if projectNamespace.Kind == "user" {
-				log.Debugf(
-					"%s: Creating new gitlab project '%s' on '%s' for user '%s'",
-					repo.sha, projectNameShort, baseURL, projectNamespace.Path)
-				gitlabObj.CreateProject(
-					repo.sha,
-					baseURL,
-					projectNameShort,
-					0,
-					mirrorVisibilityMode,
-					repo.URL,
-				)
-			} else {
-				log.Debugf(""+
-					"%s: Creating new gitlab project '%s' on '%s' for namespace '%s'",
-					repo.sha, projectNameShort, baseURL, projectNamespace.Path)
-				gitlabObj.CreateProject(
-					repo.sha,
-					baseURL,
-					projectNameShort,
-					projectNamespace.ID,
-					mirrorVisibilityMode,
-					repo.URL,
-				)
-			}
+			log.Debugf(
+				"%s: Creating new gitlab project '%s' on '%s' for %s namespace '%s'",
+				repo.sha, projectPath, baseURL, projectNamespace.Kind, projectNamespace.FullPath)
+			namespaceID = projectNamespace.ID
 		} else {
-			// MAYBE: space for improvement - ensure group with subgroups is created, then create project
-			log.Fatalf(
-				"%s: Group '%s' does not exist, please ensure it is created using for mirrors",
-				repo.sha, namespaceFullPath)
-			os.Exit(1)
+			log.Debugf("%s: Gitlab group '%s' does not exist, creating group hierarchy", repo.sha, namespaceFullPath)
+			group := gitlabObj.EnsureGroupPath(repo.sha, baseURL, namespaceFullPath, mirrorVisibilityMode)
+			log.Debugf(
+				"%s: Creating new gitlab project '%s' on '%s' for namespace '%s'",
+				repo.sha, projectPath, baseURL, group.FullPath)
+			namespaceID = group.ID
 		}
+		gitlabObj.CreateProject(
+			repo.sha,
+			baseURL,
+			projectName,
+			projectPath,
+			namespaceID,
+			mirrorVisibilityMode,
+			repo.URL,
+		)
+		repo.status.Mirror.Created = true
 	}
 }
 
+// DecomposeGitURL - returns host, full path and short name of the repository URL, fails if URL has
+// no path after the host
 func DecomposeGitURL(gitURL string) (baseURL, fullName, shortName string) {
+	baseURL, fullName, shortName, err := decomposeGitURL(gitURL)
+	if err != nil {
+		log.Fatalf("Error: %s", err)
+		os.Exit(1)
+	}
+
+	return baseURL, fullName, shortName
+}
+
+// gitURLHost - returns host of the git URL, URL may have no path ( ssh://git@abc.com:7999 -> abc.com )
+func gitURLHost(gitURL string) string {
+	baseURL, _, _, _ := decomposeGitURL(gitURL)
+
+	return baseURL
+}
+
+// validateMirrorURL - ensures mirror root URL specifies host and path, empty URL is allowed for dry-run
+func validateMirrorURL(mirrorRootURL string) {
+	if mirrorRootURL != "" {
+		DecomposeGitURL(mirrorRootURL)
+	}
+}
+
+// decomposeGitURL - returns host, full path and short name of the repository URL, host is returned
+// together with error if URL has no path
+func decomposeGitURL(gitURL string) (baseURL, fullName, shortName string, err error) {
 	// input: git@abc.com:b/c/d.git or https://abc.com/b/c/d.git -> abc.com/b/c/d
+	// or ssh://git@abc.com:7999/b/c/d.git -> abc.com/b/c/d
+	// remove port of the url scheme based git repo url, scp like urls can't have port
+	if strings.Contains(gitURL, "://") {
+		re := regexp.MustCompile(`^([a-z+]+://[^/]*):[0-9]+(/|$)`)
+		gitURL = re.ReplaceAllString(gitURL, "$1$2")
+	}
 	// remove unwanted parts of the git repo url
-	re := regexp.MustCompile(`.git$|^https://|^git@`)
+	re := regexp.MustCompile(`^https://|^http://|^ssh://`)
 	url := re.ReplaceAllString(gitURL, "")
+	re = regexp.MustCompile(`.git$|^git@`)
+	url = re.ReplaceAllString(url, "")
 	re = regexp.MustCompile(`:`)
 	url = re.ReplaceAllString(url, "/")
 
 	// baseURL and longPath for checking repo existence ( abc.com/b/c/d -> abc.com , b/c/d )
 	urlParts := strings.SplitN(url, "/", 2)
+	if len(urlParts) < 2 || urlParts[0] == "" || urlParts[1] == "" {
+		return urlParts[0], "", "", fmt.Errorf("git URL '%s' must specify host and path (example: git@github.com:acmeorg)", gitURL)
+	}
 	baseURL, fullName = urlParts[0], urlParts[1]
 
 	// baseURL and project Name for creating missing repository ( abc.com/b/c/d -> abc.com/b/c , d)
 	_, shortName = filepath.Split(url)
 
 	// ( abc.com/b/c/d -> abc.com, b/c/d, d )
-	return baseURL, fullName, shortName
+	return baseURL, fullName, shortName, nil
 }
 
 // EnsureGithubMirrorExists - creates mirror repository if it does not exist
 func (repo *Repo) EnsureGithubMirrorExists() {
-	_, projectNameFullPath, _ := DecomposeGitURL(repo.mirrorURL)
+	baseURL, projectNameFullPath, _ := DecomposeGitURL(repo.mirrorURL)
 	repoNameParts := strings.SplitN(projectNameFullPath, "/", 2)
 	workspaceName, repositoryName := repoNameParts[0], repoNameParts[1]
 	ctx := context.Background()
-	if !github.RepositoryExists(ctx, repo.sha, workspaceName, repositoryName) {
+	githubObj := repo.providers.Github
+
+	if !githubObj.RepositoryExists(ctx, repo.sha, baseURL, workspaceName, repositoryName) {
 		log.Debugf("%s: Creating new github repository '%s'", repo.sha, repo.mirrorURL)
-		github.CreateRepository(ctx, repo.sha, repositoryName, mirrorVisibilityMode, repo.URL)
+		githubObj.CreateRepository(ctx, repo.sha, baseURL, workspaceName, repositoryName, mirrorVisibilityMode, repo.URL)
+		repo.status.Mirror.Created = true
 	} else {
 		log.Debugf("%s: github repository '%s' exists", repo.sha, repo.mirrorURL)
 	}
+
+	if githubMirrorTeam != "" {
+		repo.ensureGithubTeamRepository(ctx, baseURL, workspaceName, repositoryName)
+	}
+}
+
+// ensureGithubTeamRepository - grants mirror team permission on created mirror repository or existing
+// one team has no access to, team access is not required to mirror, so failures are only reported
+func (repo *Repo) ensureGithubTeamRepository(ctx context.Context, baseURL, org, repositoryName string) {
+	githubObj := repo.providers.Github
+
+	if !repo.status.Mirror.Created {
+		hasAccess, err := githubObj.TeamHasRepository(ctx, repo.sha, baseURL, org, githubMirrorTeam, repositoryName)
+		if err != nil {
+			log.Warnf("%s: Unable to check team '%s' access to github repository '%s/%s': %s",
+				repo.sha, githubMirrorTeam, org, repositoryName, err)
+			return
+		}
+		if hasAccess {
+			log.Debugf("%s: Team '%s' has access to github repository '%s/%s'",
+				repo.sha, githubMirrorTeam, org, repositoryName)
+			return
+		}
+	}
+
+	err := githubObj.AddTeamRepository(
+		ctx, repo.sha, baseURL, org, githubMirrorTeam, repositoryName, githubMirrorTeamPermission)
+	if err != nil {
+		log.Warnf("%s: Unable to grant team '%s' permission on github repository '%s/%s': %s",
+			repo.sha, githubMirrorTeam, org, repositoryName, err)
+	}
+}
+
+// validateGithubMirrorTeam - ensures mirror owner is an organization with the mirror team, so that team
+// permission can be granted on mirror repositories
+func validateGithubMirrorTeam(githubObj github.GitGetGithubI, mirrorRootURL string) {
+	baseURL, owner, _ := DecomposeGitURL(strings.TrimSuffix(mirrorRootURL, "/"))
+	githubObj.ValidateTeam(context.Background(), "", baseURL, owner, githubMirrorTeam)
 }
 
 // EnsureBitbucketMirrorExists - creates mirror repository if it does not exist
@@ -684,14 +858,48 @@ func (repo *Repo) EnsureBitbucketMirrorExists() {
 	_, fullName, _ := DecomposeGitURL(repo.mirrorURL)
 	repoNameParts := strings.SplitN(fullName, "/", 2)
 	workspaceName, repositoryName := repoNameParts[0], repoNameParts[1]
-	if !bitbucket.RepositoryExists(repo.sha, workspaceName, repositoryName) {
+	if bitbucketServerURL != "" {
+		repo.EnsureBitbucketServerMirrorExists(workspaceName, repositoryName)
+		return
+	}
+	bitbucketObj := repo.providers.Bitbucket
+
+	if !bitbucketObj.RepositoryExists(repo.sha, workspaceName, repositoryName) {
 		log.Debugf("%s: Creating new bitbucket repository '%s'", repo.sha, repo.mirrorURL)
-		bitbucket.CreateRepository(repo.sha, fullName, mirrorVisibilityMode, repo.URL, bitbucketMirrorProject)
+		bitbucketObj.CreateRepository(repo.sha, fullName, mirrorVisibilityMode, repo.URL, bitbucketMirrorProject)
+		repo.status.Mirror.Created = true
 	} else {
 		log.Debugf("%s: bitbucket repository '%s' exists", repo.sha, repo.mirrorURL)
 	}
 }
 
+// EnsureBitbucketServerMirrorExists - creates mirror repository in Bitbucket Server project if it does not exist,
+// mirror URL is updated to the repository slug, as Bitbucket Server serves repositories by slug, not by name
+func (repo *Repo) EnsureBitbucketServerMirrorExists(projectKey, repositoryName string) {
+	bitbucketServerObj := repo.providers.BitbucketServer
+	slug := bitbucketserver.RepositorySlug(repositoryName)
+
+	if !bitbucketServerObj.RepositoryExists(repo.sha, bitbucketServerURL, projectKey, repositoryName) {
+		log.Debugf("%s: Creating new bitbucket server repository '%s'", repo.sha, repo.mirrorURL)
+		created := bitbucketServerObj.CreateRepository(
+			repo.sha, bitbucketServerURL, projectKey, repositoryName, mirrorVisibilityMode, repo.URL)
+		if created != nil && created.Slug != "" {
+			slug = created.Slug
+		}
+		repo.status.Mirror.Created = true
+	} else {
+		log.Debugf("%s: bitbucket server repository '%s' exists", repo.sha, repo.mirrorURL)
+	}
+
+	repo.mirrorURL = bitbucketServerSlugURL(repo.mirrorURL, slug)
+}
+
+// bitbucketServerSlugURL - replaces repository name of the mirror URL with the repository slug
+// ( ssh://git@bbs.acme.com:7999/MIRRORS/My Repo.git + my-repo -> ssh://git@bbs.acme.com:7999/MIRRORS/my-repo.git )
+func bitbucketServerSlugURL(mirrorURL, slug string) string {
+	return mirrorURL[:strings.LastIndex(mirrorURL, "/")+1] + slug + ".git"
+}
+
 func getShallowReposFromConfigInParallel(repoList *RepoList, ignoreRepoList []Repo, concurrencyLevel int) {
 	throttle := make(chan int, concurrencyLevel)
 
@@ -706,14 +914,28 @@ func getShallowReposFromConfigInParallel(repoList *RepoList, ignoreRepoList []Re
 			if !ignoreThisRepo(repository.URL, ignoreRepoList) {
 				repository.PrepareForGet()
 				log.Debugf("%s: process repo: '%s'", repository.sha, repository.URL)
-				if repository.RepoPathExists() {
-					log.Debugf("%s: path '%s' exists - removing target path", repository.sha, repository.fullPath)
-					repository.RemoveTargetDir(false)
+				if shallowKeepGit && repository.GitDirExists() {
+					log.Debugf("%s: path '%s' exists - refreshing shallow clone", repository.sha, repository.fullPath)
+					if repository.ShallowRefresh() {
+						repository.UpdateSubmodules(true)
+					}
+				} else {
+					if repository.RepoPathExists() {
+						log.Debugf("%s: path '%s' exists - removing target path", repository.sha, repository.fullPath)
+						repository.RemoveTargetDir(false)
+					}
+					log.Debugf("%s: path '%s' missing - performing shallow clone", repository.sha, repository.fullPath)
+					if repository.ShallowClone() {
+						repository.UpdateSubmodules(true)
+					}
+					if !shallowKeepGit {
+						// remove .git inside the cloned path
+						repository.RemoveTargetDir(true)
+						if repository.submodulesUpdated {
+							repository.RemoveSubmodulesGitFiles()
+						}
+					}
 				}
-				log.Debugf("%s: path '%s' missing - performing shallow clone", repository.sha, repository.fullPath)
-				repository.ShallowClone()
-				// remove .git inside the cloned path
-				repository.RemoveTargetDir(true)
 				repository.ProcessSymlinks()
 
 				repository.status.Processed = true
@@ -744,11 +966,15 @@ func getReposFromConfigInParallel(repoList *RepoList, ignoreRepoList []Repo, con
 				if !repository.RepoPathExists() {
 					// Clone
 					log.Debugf("%s: path '%s' missing - cloning", repository.sha, repository.fullPath)
-					repository.Clone()
+					if repository.Clone() {
+						repository.UpdateSubmodules(false)
+					}
 				} else {
 					// Refresh
 					log.Debugf("%s: path '%s' exists, will refresh from remote", repository.sha, repository.fullPath)
 					repository.ProcessRepoBasedOnCurrentBranch()
+					repository.SparseCheckout()
+					repository.UpdateSubmodules(false)
 				}
 				repository.ProcessSymlinks()
 				repository.status.Processed = true
@@ -767,18 +993,23 @@ func mirrorReposFromConfigInParallel(
 	concurrencyLevel int,
 	pushMirror bool,
 	mirrorRootURL string,
+	providers *Providers,
 ) {
 	throttle := make(chan int, concurrencyLevel)
 
 	var wait sync.WaitGroup
 
-	// make temp directory - preserve its name
-	tempDir, err := os.MkdirTemp("", "gitgetmirror")
-	if err != nil {
-		log.Fatalf("Error: %s, while creating temporary directory", err)
-		os.Exit(1)
+	// repositories are cloned to temp directory, unless mirror cache directory is used
+	workDir := mirrorCacheDir
+	if workDir == "" {
+		tempDir, err := os.MkdirTemp("", "gitgetmirror")
+		if err != nil {
+			log.Fatalf("Error: %s, while creating temporary directory", err)
+			os.Exit(1)
+		}
+		defer os.RemoveAll(tempDir)
+		workDir = tempDir
 	}
-	defer os.RemoveAll(tempDir)
 
 	for i := 0; i < len(*repoList); i++ {
 		throttle <- 1
@@ -789,16 +1020,31 @@ func mirrorReposFromConfigInParallel(
 
 			if !ignoreThisRepo(repository.URL, ignoreRepoList) {
 				log.Debugf("%s: process repo: '%s'", repository.sha, repository.URL)
-				repository.PrepareForMirror(tempDir, mirrorRootURL)
-				// Clone
-				log.Debugf("%s: path '%s' cloning for mirror", repository.sha, repository.fullPath)
-				repository.CloneMirror()
-				if pushMirror {
-					repository.EnsureMirrorExists()
-					repository.PushMirror()
+				start := time.Now()
+				repository.PrepareForMirror(workDir, mirrorRootURL)
+				repository.SetProviders(providers)
+				if mirrorMode == MirrorModeNative {
+					repository.MirrorNative(pushMirror)
+				} else if mirrorCacheDir != "" {
+					repository.mirrorFromCache(pushMirror, mirrorCacheFsck)
 				} else {
-					log.Infof("%s: skipping '%s' remote push per user request", repository.sha, repository.URL)
+					// Clone
+					log.Debugf("%s: path '%s' cloning for mirror", repository.sha, repository.fullPath)
+					if !repository.CloneMirror(repository.fullPath) {
+						log.Errorf("%s: skipping '%s' remote push, repository was not cloned", repository.sha, repository.URL)
+					} else if pushMirror {
+						repository.EnsureMirrorExists()
+						repository.MirrorLFS()
+						repository.PushMirror()
+					} else {
+						log.Infof("%s: skipping '%s' remote push per user request", repository.sha, repository.URL)
+					}
+				}
+				if pushMirror && mirrorSyncMetadata && !repository.status.Error {
+					repository.SyncMirrorMetadata()
 				}
+				repository.status.Mirror.Duration = time.Since(start)
+				repository.status.Processed = true
 			}
 
 			<-ithrottle
@@ -815,20 +1061,30 @@ func GetRepositories(
 	concurrencyLevel int,
 	stickToRef bool,
 	shallow bool,
+	keepGit bool,
 	defaultTrunkBranch string,
 	status bool,
+	httpsAuth bool,
+	submodules string,
+	referenceCache string,
 ) {
 	initColors()
 	stayOnRef = stickToRef
+	shallowKeepGit = keepGit
 	defaultMainBranch = defaultTrunkBranch
+	httpsTokenAuth = httpsAuth
+	submodulesMode = submodules
+	referenceCacheDir = referenceCache
 
 	repoList := GetConfigRepoList(cfgFiles)
 	log.Debugf("Total number of repositories to process: '%d'", len(*repoList))
+	validateSubmodules(repoList)
+	validateCloneFilters(repoList)
 
 	ignoreRepoList := GetIgnoreRepoList(ignoreFiles)
 	log.Debugf("Total number of repositories to ignore: '%d'", len(ignoreRepoList))
 
-	if shallow {
+	if shallow || shallowKeepGit {
 		getShallowReposFromConfigInParallel(repoList, ignoreRepoList, concurrencyLevel)
 	} else {
 		getReposFromConfigInParallel(repoList, ignoreRepoList, concurrencyLevel)
@@ -840,21 +1096,23 @@ func GetRepositories(
 
 	if status {
 		fmt.Fprintln(w)
-		fmt.Fprintln(w, "REPOSITORY\tPATH\tLOCAL_CHANGES\tNOT_ON_REF\tERROR\tSKIPPED\tCLEAN")
+		fmt.Fprintln(w, "REPOSITORY\tPATH\tLOCAL_CHANGES\tNOT_ON_REF\tERROR\tSUBMODULES_ERROR\tSKIPPED\tCLEAN")
 		for _, repo := range *repoList {
-			clean := !(!repo.status.Processed || repo.status.UncommittedChanges || repo.status.NotOnRefBranch || repo.status.Error)
+			clean := !(!repo.status.Processed || repo.status.UncommittedChanges || repo.status.NotOnRefBranch ||
+				repo.status.Error || repo.status.SubmodulesError)
 			if repo.status.Processed {
-				fmt.Fprintf(w, "%s\t%s\t%t\t%t\t%t\t%t\t%t\n",
+				fmt.Fprintf(w, "%s\t%s\t%t\t%t\t%t\t%t\t%t\t%t\n",
 					repo.URL,
 					repo.fullPath,
 					repo.status.UncommittedChanges,
 					repo.status.NotOnRefBranch,
 					repo.status.Error,
+					repo.status.SubmodulesError,
 					!repo.status.Processed,
 					clean,
 				)
 			} else {
-				fmt.Fprintf(w, "%s\t-\t-\t-\t-\t%t\t%t\n", repo.URL, !repo.status.Processed, clean)
+				fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t%t\t%t\n", repo.URL, !repo.status.Processed, clean)
 			}
 		}
 		fmt.Fprintln(w)
@@ -872,52 +1130,96 @@ func ignoreThisRepo(repoURL string, ignoreRepoList []Repo) bool {
 	return false
 }
 
+// githubRepoPath - constructs Gitfile 'path' for github repository from the non empty parts
+func githubRepoPath(targetClonePath, owner, teamPath string, pathByOwner bool) string {
+	repoPath := targetClonePath
+	if pathByOwner {
+		repoPath = path.Join(repoPath, owner)
+	}
+	return path.Join(repoPath, teamPath)
+}
+
+func githubRepoDefinition(repoSha string, ghRepo *gh.Repository, gitSchema, repoPath string) Repo {
+	var gitGetRepoDefinition Repo
+	switch gitSchema {
+	case SSH:
+		gitGetRepoDefinition = Repo{
+			URL: ghRepo.GetSSHURL(),
+			Ref: ghRepo.GetDefaultBranch(),
+		}
+	case HTTPS:
+		gitGetRepoDefinition = Repo{
+			URL: ghRepo.GetHTMLURL(),
+			Ref: ghRepo.GetDefaultBranch(),
+		}
+	default:
+		log.Fatalf("%s: Error: unknown '%s' git schema", repoSha, gitSchema)
+		os.Exit(1)
+	}
+
+	if repoPath != "" {
+		gitGetRepoDefinition.Path = repoPath
+	}
+
+	return gitGetRepoDefinition
+}
+
 func fetchGithubRepos(
 	repoSha string,
 	ignoreRepoList []Repo,
 	gitCloudProviderRootURL string,
 	targetClonePath string,
 	configGenParams *ConfigGenParamsStruct,
+	providers *Providers,
 ) []Repo {
 	var repoList []Repo
 	ctx := context.Background()
-	_, owner, _ := DecomposeGitURL(gitCloudProviderRootURL)
-	log.Infof("%s: Fetching repositories for '%s' target: '%s'", repoSha, gitProvider, gitCloudProviderRootURL)
+	baseURL, owner, _ := DecomposeGitURL(gitCloudProviderRootURL)
+	log.Infof(
+		"%s: Fetching repositories for '%s' target: '%s' -> '%s' '%s'",
+		repoSha, gitProvider, gitCloudProviderRootURL, baseURL, owner)
 
-	ghRepoList := github.FetchOwnerRepos(
-		ctx,
-		repoSha,
-		owner,
-		configGenParams.GithubVisibility,
-		configGenParams.GithubAffiliation,
-	)
-	log.Debugf("%s: Number of fetched repositories: '%d'", repoSha, len(ghRepoList))
+	githubObj := providers.Github
 
-	for repo := 0; repo < len(ghRepoList); repo++ {
-		var gitGetRepoDefinition Repo
-		switch configGenParams.GitSchema {
-		case SSH:
-			gitGetRepoDefinition = Repo{
-				URL: *ghRepoList[repo].SSHURL,
-				Ref: *ghRepoList[repo].DefaultBranch,
-			}
-		case HTTPS:
-			gitGetRepoDefinition = Repo{
-				URL: *ghRepoList[repo].HTMLURL,
-				Ref: *ghRepoList[repo].DefaultBranch,
-			}
-		default:
-			log.Fatalf("%s: Error: unknown '%s' git schema", repoSha, configGenParams.GitSchema)
-			os.Exit(1)
-		}
+	var teamRepoList []github.TeamRepositories
+	if configGenParams.GithubTeam != "" {
+		teamRepoList = githubObj.FetchTeamRepos(ctx, repoSha, baseURL, owner, configGenParams.GithubTeam)
+	} else {
+		teamRepoList = []github.TeamRepositories{{
+			Repositories: githubObj.FetchOwnerRepos(
+				ctx,
+				repoSha,
+				baseURL,
+				owner,
+				configGenParams.GithubVisibility,
+				configGenParams.GithubAffiliation,
+			),
+		}}
+	}
 
-		if targetClonePath != "" {
-			gitGetRepoDefinition.Path = targetClonePath
-		}
+	for team := 0; team < len(teamRepoList); team++ {
+		ghRepoList := teamRepoList[team].Repositories
+		log.Debugf(
+			"%s: Number of fetched repositories for '%s': '%d'",
+			repoSha, teamRepoList[team].Path, len(ghRepoList))
 
-		if !ignoreThisRepo(gitGetRepoDefinition.URL, ignoreRepoList) {
-			log.Debugf("%s: adding repo: '%s'", repoSha, gitGetRepoDefinition.URL)
-			repoList = append(repoList, gitGetRepoDefinition)
+		for repo := 0; repo < len(ghRepoList); repo++ {
+			gitGetRepoDefinition := githubRepoDefinition(
+				repoSha,
+				ghRepoList[repo],
+				configGenParams.GitSchema,
+				githubRepoPath(
+					targetClonePath,
+					ghRepoList[repo].GetOwner().GetLogin(),
+					teamRepoList[team].Path,
+					configGenParams.GithubPathByOwner,
+				),
+			)
+
+			if !ignoreThisRepo(gitGetRepoDefinition.URL, ignoreRepoList) {
+				log.Debugf("%s: adding repo: '%s'", repoSha, gitGetRepoDefinition.URL)
+				repoList = append(repoList, gitGetRepoDefinition)
+			}
 		}
 	}
 
@@ -964,20 +1266,50 @@ func getBitbucketRepositoryGitURL(
 	return guessWorkRepoURL
 }
 
+// bitbucketProjectSelected - returns true if no project filter is specified or project key is in the list
+func bitbucketProjectSelected(projectKey string, projectKeys []string) bool {
+	if len(projectKeys) == 0 {
+		return true
+	}
+	for _, key := range projectKeys {
+		if strings.EqualFold(key, projectKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func fetchBitbucketRepos(
 	repoSha string,
 	ignoreRepoList []Repo,
 	gitCloudProviderRootURL string,
 	targetClonePath string,
 	configGenParams *ConfigGenParamsStruct,
+	providers *Providers,
 ) []Repo {
 	var repoList []Repo
 
-	_, owner, _ := DecomposeGitURL(gitCloudProviderRootURL)
 	log.Infof("%s: Fetching repositories for '%s' target: '%s'", repoSha, gitProvider, gitCloudProviderRootURL)
-	bbRepoList := bitbucket.FetchOwnerRepos(
+	if configGenParams.BitbucketServerURL != "" {
+		// Bitbucket Server URL without project selects repositories of all projects
+		_, owner, _, _ := decomposeGitURL(gitCloudProviderRootURL)
+		return fetchBitbucketServerRepos(repoSha, ignoreRepoList, owner, targetClonePath, configGenParams, providers)
+	}
+	_, owner, _ := DecomposeGitURL(gitCloudProviderRootURL)
+	bitbucketObj := providers.Bitbucket
+
+	bbRepoList := bitbucketObj.FetchOwnerRepos(
 		repoSha, owner, configGenParams.BitbucketRole)
 	for repo := 0; repo < len(bbRepoList); repo++ {
+		projectKey := bbRepoList[repo].Project.Key
+		if !bitbucketProjectSelected(projectKey, configGenParams.BitbucketProjects) {
+			log.Debugf(
+				"%s: skipping repo '%s' from project '%s'",
+				repoSha, bbRepoList[repo].Full_name, projectKey)
+			continue
+		}
+
 		gitGetRepoDefinition := Repo{
 			URL: getBitbucketRepositoryGitURL(
 				repoSha,
@@ -988,7 +1320,9 @@ func fetchBitbucketRepos(
 			),
 			Ref: bbRepoList[repo].Mainbranch.Name,
 		}
-		if targetClonePath != "" {
+		if configGenParams.BitbucketPathByProject {
+			gitGetRepoDefinition.Path = path.Join(targetClonePath, projectKey)
+		} else if targetClonePath != "" {
 			gitGetRepoDefinition.Path = targetClonePath
 		}
 
@@ -1001,12 +1335,66 @@ func fetchBitbucketRepos(
 	return repoList
 }
 
+// fetchBitbucketServerRepos - Bitbucket Server repositories always belong to a project, repositories
+// are fetched for project specified by URL or for the projects specified via project filter, or for
+// all projects visible to the user if neither is specified
+func fetchBitbucketServerRepos(
+	repoSha string,
+	ignoreRepoList []Repo,
+	owner string,
+	targetClonePath string,
+	configGenParams *ConfigGenParamsStruct,
+	providers *Providers,
+) []Repo {
+	var repoList []Repo
+
+	bitbucketServerObj := providers.BitbucketServer
+	projectKeys := configGenParams.BitbucketProjects
+	if len(projectKeys) == 0 && owner != "" {
+		projectKeys = []string{owner}
+	}
+	if len(projectKeys) == 0 {
+		for _, project := range bitbucketServerObj.FetchProjects(repoSha, configGenParams.BitbucketServerURL) {
+			projectKeys = append(projectKeys, project.Key)
+		}
+	}
+
+	for _, projectKey := range projectKeys {
+		bbRepoList := bitbucketServerObj.FetchProjectRepos(repoSha, configGenParams.BitbucketServerURL, projectKey)
+		for repo := 0; repo < len(bbRepoList); repo++ {
+			gitGetRepoDefinition := Repo{
+				URL: bbRepoList[repo].CloneURL(configGenParams.GitSchema),
+				Ref: bbRepoList[repo].DefaultBranch,
+			}
+			if gitGetRepoDefinition.URL == "" {
+				log.Errorf(
+					"%s: repo '%s/%s' has no '%s' clone link, skipping",
+					repoSha, projectKey, bbRepoList[repo].Slug, configGenParams.GitSchema)
+				continue
+			}
+			if configGenParams.BitbucketPathByProject {
+				gitGetRepoDefinition.Path = path.Join(targetClonePath, bbRepoList[repo].Project.Key)
+			} else if targetClonePath != "" {
+				gitGetRepoDefinition.Path = targetClonePath
+			}
+
+			if !ignoreThisRepo(gitGetRepoDefinition.URL, ignoreRepoList) {
+				log.Debugf("%s: adding repo: '%s'", repoSha, gitGetRepoDefinition.URL)
+				repoList = append(repoList, gitGetRepoDefinition)
+			}
+		}
+	}
+
+	return repoList
+}
+
 func fetchGitlabRepos(
 	repoSha string,
 	ignoreRepoList []Repo,
 	gitCloudProviderRootURL string,
 	targetClonePath string,
 	configGenParams *ConfigGenParamsStruct,
+	providers *Providers,
 ) []Repo {
 	var repoList []Repo
 
@@ -1015,8 +1403,7 @@ func fetchGitlabRepos(
 		"%s: Fetching repositories for '%s' target: '%s' -> '%s' '%s'",
 		repoSha, gitProvider, gitCloudProviderRootURL, baseURL, groupName)
 
-	gitlabObj := gitlab.GitGetGitlab{}
-	gitlabObj.Init()
+	gitlabObj := providers.Gitlab
 
 	glRepoList := gitlabObj.FetchOwnerRepos(
 		repoSha,
@@ -1147,6 +1534,9 @@ func GenerateGitfileConfig(
 	log.Debugf("Total number of repositories to ignore: '%d'", len(ignoreRepoList))
 
 	gitProvider = gitCloudProvider
+	githubCABundle = configGenParams.GithubCABundle
+	bitbucketServerURL = configGenParams.BitbucketServerURL
+	providers := NewProviders(gitCloudProvider, gitCloudProviderRootURL)
 
 	switch gitCloudProvider {
 	case "github":
@@ -1156,6 +1546,7 @@ func GenerateGitfileConfig(
 			gitCloudProviderRootURL,
 			targetClonePath,
 			configGenParams,
+			providers,
 		)
 	case "gitlab":
 		repoList = fetchGitlabRepos(
@@ -1164,6 +1555,7 @@ func GenerateGitfileConfig(
 			gitCloudProviderRootURL,
 			targetClonePath,
 			configGenParams,
+			providers,
 		)
 	case "bitbucket":
 		repoList = fetchBitbucketRepos(
@@ -1172,6 +1564,7 @@ func GenerateGitfileConfig(
 			gitCloudProviderRootURL,
 			targetClonePath,
 			configGenParams,
+			providers,
 		)
 	default:
 		log.Fatalf("%s: Error: unknown '%s' git mirror provider", repoSha, gitCloudProvider)
@@ -1179,6 +1572,7 @@ func GenerateGitfileConfig(
 	}
 
 	writeReposToFile(repoSha, cfgFile, repoList)
+	logAPICalls()
 }
 
 // MirrorRepositories - Entry point for mirror creation/update logic
@@ -1191,11 +1585,54 @@ func MirrorRepositories(
 	mirrorProviderName string,
 	mirrorVisibilityModeName string,
 	mirrorBitbucketProjectName string,
+	mirrorBitbucketServerURL string,
+	mirrorGithubCABundle string,
+	httpsAuth bool,
+	mirrorNamingStrategy string,
+	mirrorNamingTemplate string,
+	cacheDir string,
+	cacheFsck bool,
+	pushRefs []string,
+	pushExcludeRefs []string,
+	pushNoDelete bool,
+	mode string,
+	syncMetadata bool,
+	mirrorGithubTeam string,
+	mirrorGithubTeamPermission string,
+	mirrorGitlabNamespaceID int64,
+	status bool,
+	reportFile string,
 ) {
 	initColors()
 	gitProvider = mirrorProviderName
 	mirrorVisibilityMode = mirrorVisibilityModeName
 	bitbucketMirrorProject = mirrorBitbucketProjectName
+	bitbucketServerURL = mirrorBitbucketServerURL
+	githubCABundle = mirrorGithubCABundle
+	githubMirrorTeam = mirrorGithubTeam
+	githubMirrorTeamPermission = mirrorGithubTeamPermission
+	gitlabMirrorNamespaceID = mirrorGitlabNamespaceID
+	httpsTokenAuth = httpsAuth
+	mirrorNaming = mirrorNamingStrategy
+	validateMirrorNaming(mirrorNamingTemplate)
+	mirrorCacheDir = cacheDir
+	mirrorCacheFsck = cacheFsck
+	mirrorRefs = pushRefs
+	mirrorExcludeRefs = pushExcludeRefs
+	mirrorNoDelete = pushNoDelete
+	mirrorMode = mode
+	mirrorSyncMetadata = syncMetadata
+	validateMetadataSync()
+	validateMirrorMode()
+	validateGitlabNamespaceID()
+	validateMirrorURL(mirrorRootURL)
+	setHostProvider(mirrorRootURL, gitProvider, nil)
+	if mirrorCacheDir != "" {
+		if err := os.MkdirAll(mirrorCacheDir, 0o755); err != nil {
+			log.Fatalf("Error: %s, while creating mirror cache directory", err)
+			os.Exit(1)
+		}
+	}
 
 	repoList := GetConfigRepoList(cfgFiles)
 	log.Debugf("Total number of repositories to process: '%d'", len(*repoList))
@@ -1203,5 +1640,36 @@ func MirrorRepositories(
 	ignoreRepoList := GetIgnoreRepoList(ignoreFiles)
 	log.Debugf("Total number of repositories to ignore: '%d'", len(ignoreRepoList))
 
-	mirrorReposFromConfigInParallel(repoList, ignoreRepoList, concurrencyLevel, pushMirror, mirrorRootURL)
+	var providers *Providers
+	if gitlabMirrorNamespaceID != 0 {
+		providers = NewProviders(mirrorProviderName, mirrorRootURL)
+		mirrorRootURL = gitlabNamespaceRootURL(providers.Gitlab, mirrorRootURL)
+	}
+
+	checkMirrorNames(repoList, ignoreRepoList, mirrorRootURL)
+	if mirrorMode == MirrorModeNative {
+		checkNativeSourceURLs(repoList, ignoreRepoList)
+	}
+
+	if pushMirror {
+		if providers == nil {
+			providers = NewProviders(mirrorProviderName, mirrorRootURL)
+		}
+		if gitProvider == "github" && githubMirrorTeam != "" {
+			validateGithubMirrorTeam(providers.Github, mirrorRootURL)
+		}
+		if mirrorSyncMetadata {
+			providers.Sources = newSourceProviders(repoList, ignoreRepoList)
+		}
+	}
+
+	mirrorReposFromConfigInParallel(repoList, ignoreRepoList, concurrencyLevel, pushMirror, mirrorRootURL, providers)
+	reportLFSErrors(repoList)
+	if status {
+		printMirrorStatus(repoList)
+	}
+	if reportFile != "" {
+		writeMirrorReport(reportFile, repoList)
+	}
+	logAPICalls()
 }
@@ -6,12 +6,21 @@ package gitget
 import (
 	"bytes"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	gh "github.com/google/go-github/v81/github"
+	"github.com/isindir/git-get/bitbucketserver"
 	"github.com/isindir/git-get/exec/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var repoUrls = map[string]string{
@@ -317,8 +326,9 @@ func Test_Repo_CloneMirror(t *testing.T) {
 
 			tc.repo.SetShellRunner(mockGitExec)
 
-			result := tc.repo.CloneMirror()
+			result := tc.repo.CloneMirror(tc.repo.fullPath)
 			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, !tc.expectedResult, tc.repo.status.Error)
 		})
 	}
 }
@@ -497,13 +507,12 @@ func Test_Repo_PushMirror(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mockGitExec := new(mocks.ShellRunnerI)
 			exe := &exec.Cmd{}
-			serr := &bytes.Buffer{}
 
 			mockGitExec.On(
 				"ExecGitCommand",
-				[]string{"push", "--mirror", tc.repo.mirrorURL},
-				(*bytes.Buffer)(nil),
-				serr,
+				[]string{"push", "--porcelain", "--progress", "--mirror", tc.repo.mirrorURL},
+				mock.AnythingOfType("*bytes.Buffer"),
+				mock.AnythingOfType("*bytes.Buffer"),
 				"").Return(exe, tc.returnError)
 
 			tc.repo.SetShellRunner(mockGitExec)
@@ -686,3 +695,215 @@ func Test_InitColors(t *testing.T) {
 	assert.NotNil(t, colorHighlight)
 	assert.NotNil(t, colorRef)
 }
+
+func Test_githubRepoPath(t *testing.T) {
+	type testCase struct {
+		name            string
+		targetClonePath string
+		owner           string
+		teamPath        string
+		pathByOwner     bool
+		expectedResult  string
+	}
+
+	testCases := []testCase{
+		{name: "no path", expectedResult: ""},
+		{name: "target path only", targetClonePath: "src", owner: "acme", expectedResult: "src"},
+		{name: "by owner", targetClonePath: "src", owner: "acme", pathByOwner: true, expectedResult: "src/acme"},
+		{name: "by owner without target", owner: "acme", pathByOwner: true, expectedResult: "acme"},
+		{name: "team", targetClonePath: "src", owner: "acme", teamPath: "platform/sre", expectedResult: "src/platform/sre"},
+		{
+			name: "team by owner", targetClonePath: "src", owner: "acme", teamPath: "platform",
+			pathByOwner: true, expectedResult: "src/acme/platform",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := githubRepoPath(tc.targetClonePath, tc.owner, tc.teamPath, tc.pathByOwner)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func Test_githubRepoDefinition(t *testing.T) {
+	ghRepo := &gh.Repository{
+		SSHURL:        gh.Ptr("git@github.com:acme/infra.git"),
+		HTMLURL:       gh.Ptr("https://github.com/acme/infra"),
+		DefaultBranch: gh.Ptr("main"),
+	}
+
+	repo := githubRepoDefinition("test-sha", ghRepo, SSH, "src/acme")
+	assert.Equal(t, Repo{URL: "git@github.com:acme/infra.git", Ref: "main", Path: "src/acme"}, repo)
+
+	repo = githubRepoDefinition("test-sha", ghRepo, HTTPS, "")
+	assert.Equal(t, Repo{URL: "https://github.com/acme/infra", Ref: "main"}, repo)
+}
+
+func Test_bitbucketProjectSelected(t *testing.T) {
+	type testCase struct {
+		name           string
+		projectKey     string
+		projectKeys    []string
+		expectedResult bool
+	}
+
+	testCases := []testCase{
+		{name: "no filter", projectKey: "OPS", projectKeys: nil, expectedResult: true},
+		{name: "in filter", projectKey: "OPS", projectKeys: []string{"KUBE", "OPS"}, expectedResult: true},
+		{name: "in filter case insensitive", projectKey: "OPS", projectKeys: []string{"ops"}, expectedResult: true},
+		{name: "not in filter", projectKey: "DEV", projectKeys: []string{"KUBE", "OPS"}, expectedResult: false},
+		{name: "repository without project", projectKey: "", projectKeys: []string{"OPS"}, expectedResult: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := bitbucketProjectSelected(tc.projectKey, tc.projectKeys)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func Test_DecomposeGitURL(t *testing.T) {
+	type testCase struct {
+		name              string
+		gitURL            string
+		expectedBaseURL   string
+		expectedFullName  string
+		expectedShortName string
+	}
+
+	testCases := []testCase{
+		{
+			name: "scp like", gitURL: "git@gitlab.com:devops/deploy/jobs.git",
+			expectedBaseURL: "gitlab.com", expectedFullName: "devops/deploy/jobs", expectedShortName: "jobs",
+		},
+		{
+			name: "https", gitURL: "https://github.com/ansible/ansible.git",
+			expectedBaseURL: "github.com", expectedFullName: "ansible/ansible", expectedShortName: "ansible",
+		},
+		{
+			name: "ssh with port", gitURL: "ssh://git@bitbucket.acme.com:7999/ops/infra.git",
+			expectedBaseURL: "bitbucket.acme.com", expectedFullName: "ops/infra", expectedShortName: "infra",
+		},
+		{
+			name: "owner only", gitURL: "git@github.com:acmeorg",
+			expectedBaseURL: "github.com", expectedFullName: "acmeorg", expectedShortName: "acmeorg",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			baseURL, fullName, shortName := DecomposeGitURL(tc.gitURL)
+			assert.Equal(t, tc.expectedBaseURL, baseURL)
+			assert.Equal(t, tc.expectedFullName, fullName)
+			assert.Equal(t, tc.expectedShortName, shortName)
+		})
+	}
+}
+
+func Test_decomposeGitURL_NoPath(t *testing.T) {
+	for _, gitURL := range []string{"", "git@github.com", "git@github.com:", "https://github.com/"} {
+		t.Run(gitURL, func(t *testing.T) {
+			_, _, _, err := decomposeGitURL(gitURL)
+			assert.Error(t, err)
+		})
+	}
+
+	// mirror URL is optional in dry-run
+	assert.NotPanics(t, func() { setHostProvider("", "github", nil) })
+
+	// Bitbucket Server config URL may have no project
+	host, _, _, err := decomposeGitURL("ssh://git@bitbucket.acme.com:7999")
+	assert.Error(t, err)
+	assert.Equal(t, "bitbucket.acme.com", host)
+	assert.Equal(t, "bitbucket.acme.com", gitURLHost("ssh://git@bitbucket.acme.com:7999"))
+	assert.Equal(t, "", gitURLHost(""))
+}
+
+func Test_bitbucketServerSlugURL(t *testing.T) {
+	assert.Equal(t,
+		"ssh://git@bitbucket.acme.com:7999/MIRRORS/my-repo.git",
+		bitbucketServerSlugURL("ssh://git@bitbucket.acme.com:7999/MIRRORS/My Repo.git", "my-repo"))
+}
+
+func Test_fetchBitbucketServerRepos_AllProjects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("start") {
+		case "0":
+			fmt.Fprint(w, `{"size":1,"limit":1,"start":0,"isLastPage":false,"nextPageStart":1,"values":[{"key":"OPS"}]}`)
+		case "1":
+			fmt.Fprint(w, `{"size":1,"limit":1,"start":1,"isLastPage":false,"nextPageStart":2,"values":[{"key":"DEV"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"size":1,"limit":1,"start":2,"isLastPage":true,"values":[{"key":"QA"}]}`)
+		default:
+			t.Errorf("unexpected start requested: '%s'", r.URL.Query().Get("start"))
+		}
+	})
+	for _, projectKey := range []string{"OPS", "DEV", "QA"} {
+		cloneURL := fmt.Sprintf("ssh://git@bitbucket.acme.com:7999/%s/infra.git", strings.ToLower(projectKey))
+		mux.HandleFunc("/rest/api/1.0/projects/"+projectKey+"/repos", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"isLastPage":true,"values":[{"slug":"infra","project":{"key":"%s"},`+
+				`"links":{"clone":[{"href":"%s","name":"ssh"}]}}]}`, projectKey, cloneURL)
+		})
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Setenv("BITBUCKET_TOKEN", "test-bitbucket-token")
+	providers := &Providers{BitbucketServer: &bitbucketserver.GitGetBitbucketServer{}}
+	providers.BitbucketServer.Init()
+	configGenParams := &ConfigGenParamsStruct{
+		GitSchema:              "ssh",
+		BitbucketPathByProject: true,
+		BitbucketServerURL:     server.URL,
+	}
+
+	repoList := fetchBitbucketServerRepos("test-sha", nil, "", "", configGenParams, providers)
+
+	require.Len(t, repoList, 3)
+	assert.Equal(t, "ssh://git@bitbucket.acme.com:7999/ops/infra.git", repoList[0].URL)
+	assert.Equal(t, "OPS", repoList[0].Path)
+	assert.Equal(t, "ssh://git@bitbucket.acme.com:7999/dev/infra.git", repoList[1].URL)
+	assert.Equal(t, "ssh://git@bitbucket.acme.com:7999/qa/infra.git", repoList[2].URL)
+	assert.Equal(t, "QA", repoList[2].Path)
+}
+
+func Test_Repo_ShallowRefresh(t *testing.T) {
+	sourceDir := filepath.Join(t.TempDir(), "source")
+	initSourceRepo(t, sourceDir, nil)
+
+	repo := Repo{URL: "file://" + sourceDir, Ref: "main", fullPath: filepath.Join(t.TempDir(), "clone")}
+	repo.SetShellRunner(shellRunner)
+	require.True(t, repo.ShallowClone())
+	assert.True(t, repo.GitDirExists())
+
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "file"), []byte("second"), 0o644))
+	gitRun(t, sourceDir, "add", "file")
+	gitRun(t, sourceDir, "commit", "-q", "-m", "second")
+	require.NoError(t, os.WriteFile(filepath.Join(repo.fullPath, "file"), []byte("local"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repo.fullPath, "untracked"), []byte("local"), 0o644))
+
+	assert.True(t, repo.ShallowRefresh())
+	content, err := os.ReadFile(filepath.Join(repo.fullPath, "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(content))
+	assert.NoFileExists(t, filepath.Join(repo.fullPath, "untracked"))
+	assert.FileExists(t, filepath.Join(repo.fullPath, ".git", "shallow"))
+
+	// repository URL changed in Gitfile is fetched instead of the cloned one
+	movedDir := filepath.Join(t.TempDir(), "moved")
+	gitRun(t, "", "clone", "-q", sourceDir, movedDir)
+	gitRun(t, movedDir, "commit", "-q", "--allow-empty", "-m", "moved")
+	repo.URL = "file://" + movedDir
+	assert.True(t, repo.ShallowRefresh())
+	var outb bytes.Buffer
+	_, err = shellRunner.ExecGitCommand([]string{"log", "-1", "--format=%s"}, &outb, nil, repo.fullPath)
+	require.NoError(t, err)
+	assert.Equal(t, "moved", strings.TrimSpace(outb.String()))
+
+	repo.Ref = "missing"
+	assert.False(t, repo.ShallowRefresh())
+	assert.True(t, repo.status.Error)
+}
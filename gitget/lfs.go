@@ -0,0 +1,105 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"bytes"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// lfsFilter - .gitattributes attribute of files stored in Git LFS
+const lfsFilter = "filter=lfs"
+
+// UsesLFS - returns true if repository is configured to use Git LFS in Gitfile or .gitattributes
+// of its default branch assigns LFS filter to any files
+func (repo *Repo) UsesLFS() bool {
+	if repo.LFS {
+		return true
+	}
+
+	var outb bytes.Buffer
+	_, err := (*repo.executor).ExecGitCommand(
+		[]string{"cat-file", "-p", "HEAD:.gitattributes"},
+		&outb,
+		nil,
+		repo.fullPath,
+	)
+
+	return err == nil && strings.Contains(outb.String(), lfsFilter)
+}
+
+// lfsCommand - runs `git lfs` command in mirror clone, failure is recorded in repository status
+func (repo *Repo) lfsCommand(args ...string) bool {
+	var serr bytes.Buffer
+	_, err := (*repo.executor).ExecGitCommand(
+		append([]string{"lfs"}, args...),
+		nil,
+		&serr,
+		repo.fullPath,
+	)
+	if err != nil {
+		repo.status.LFSError = true
+		repo.status.LFSErrorMessage = strings.TrimSpace(serr.String())
+		if repo.status.LFSErrorMessage == "" {
+			repo.status.LFSErrorMessage = err.Error()
+		}
+		log.Errorf("%s: git lfs %s: %v %v", repo.sha, args[0], err, serr.String())
+		return false
+	}
+
+	return true
+}
+
+// FetchLFS runs `git lfs fetch --all` command to download LFS objects of all refs into mirror clone
+func (repo *Repo) FetchLFS() bool {
+	log.Infof("%s: Fetch LFS objects of repository '%s'", repo.sha, repo.URL)
+	return repo.lfsCommand("fetch", "--all", "origin")
+}
+
+// PushLFS runs `git lfs push --all` command to upload LFS objects of all refs to mirror
+func (repo *Repo) PushLFS() bool {
+	log.Infof("%s: Push LFS objects of repository '%s' to mirror '%s'", repo.sha, repo.URL, repo.mirrorURL)
+	return repo.lfsCommand("push", "--all", repo.mirrorURL)
+}
+
+// MirrorLFS - copies LFS objects from source to mirror for repositories using LFS, LFS objects
+// are pushed before refs, so that mirror never has pointers to missing objects, returns false on failure
+func (repo *Repo) MirrorLFS() bool {
+	if !repo.UsesLFS() {
+		return true
+	}
+
+	return repo.FetchLFS() && repo.PushLFS()
+}
+
+// reportLFSErrors - reports repositories which LFS objects were not mirrored, refs of such
+// repositories are still mirrored
+func reportLFSErrors(repoList *RepoList) {
+	for _, repo := range *repoList {
+		if repo.status.LFSError {
+			log.Errorf("LFS objects of '%s' were not mirrored to '%s': %s", repo.URL, repo.mirrorURL, repo.status.LFSErrorMessage)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/isindir/git-get/exec/mocks"
+)
+
+func mockGitAttributes(mockGitExec *mocks.ShellRunnerI, gitAttributes string, returnError error) {
+	mockGitExec.On(
+		"ExecGitCommand",
+		[]string{"cat-file", "-p", "HEAD:.gitattributes"},
+		mock.AnythingOfType("*bytes.Buffer"),
+		(*bytes.Buffer)(nil),
+		"repo.git",
+	).Run(func(args mock.Arguments) {
+		args.Get(1).(*bytes.Buffer).WriteString(gitAttributes)
+	}).Return(&exec.Cmd{}, returnError)
+}
+
+func Test_Repo_UsesLFS(t *testing.T) {
+	testCases := []struct {
+		name           string
+		repo           Repo
+		gitAttributes  string
+		returnError    error
+		expectedResult bool
+	}{
+		{name: "gitattributes lfs", repo: Repo{fullPath: "repo.git"}, gitAttributes: "*.bin filter=lfs diff=lfs merge=lfs -text\n", expectedResult: true},
+		{name: "gitattributes without lfs", repo: Repo{fullPath: "repo.git"}, gitAttributes: "*.sh text eol=lf\n", expectedResult: false},
+		{name: "no gitattributes", repo: Repo{fullPath: "repo.git"}, returnError: fmt.Errorf("path not found"), expectedResult: false},
+		{name: "gitfile flag", repo: Repo{fullPath: "repo.git", LFS: true}, returnError: fmt.Errorf("path not found"), expectedResult: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockGitExec := new(mocks.ShellRunnerI)
+			mockGitAttributes(mockGitExec, tc.gitAttributes, tc.returnError)
+			tc.repo.SetShellRunner(mockGitExec)
+
+			assert.Equal(t, tc.expectedResult, tc.repo.UsesLFS())
+		})
+	}
+}
+
+func Test_Repo_MirrorLFS(t *testing.T) {
+	testCases := []struct {
+		name           string
+		pushError      error
+		expectedResult bool
+	}{
+		{name: "mirrored", expectedResult: true},
+		{name: "push failed", pushError: fmt.Errorf("exit status 2"), expectedResult: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := Repo{fullPath: "repo.git", mirrorURL: "git@github.com:acme/api.git"}
+			mockGitExec := new(mocks.ShellRunnerI)
+			mockGitAttributes(mockGitExec, "*.bin filter=lfs -text\n", nil)
+			mockGitExec.On(
+				"ExecGitCommand",
+				[]string{"lfs", "fetch", "--all", "origin"},
+				(*bytes.Buffer)(nil),
+				mock.AnythingOfType("*bytes.Buffer"),
+				"repo.git",
+			).Return(&exec.Cmd{}, nil)
+			mockGitExec.On(
+				"ExecGitCommand",
+				[]string{"lfs", "push", "--all", repo.mirrorURL},
+				(*bytes.Buffer)(nil),
+				mock.AnythingOfType("*bytes.Buffer"),
+				"repo.git",
+			).Return(&exec.Cmd{}, tc.pushError)
+			repo.SetShellRunner(mockGitExec)
+
+			assert.Equal(t, tc.expectedResult, repo.MirrorLFS())
+			assert.Equal(t, !tc.expectedResult, repo.status.LFSError)
+			mockGitExec.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/isindir/git-get/bitbucket"
+	"github.com/isindir/git-get/github"
+	"github.com/isindir/git-get/gitlab"
+	"github.com/isindir/git-get/metadata"
+)
+
+// bitbucketCloudHost - Bitbucket Cloud host, Bitbucket Server source hosts can't be guessed by name
+const bitbucketCloudHost = "bitbucket.org"
+
+// validateMetadataSync - ensures mirror provider supports metadata synchronisation
+func validateMetadataSync() {
+	if mirrorSyncMetadata && gitProvider == "bitbucket" && bitbucketServerURL != "" {
+		log.Fatalf("Error: metadata synchronisation is not supported by Bitbucket Server mirror provider")
+		os.Exit(1)
+	}
+}
+
+// newSourceProviders - creates API clients for hosts of source repositories, provider of self-hosted
+// source hosts is set with `provider` in credentials hosts file, metadata of repositories on hosts of
+// unknown or unsupported providers is not synchronised
+func newSourceProviders(repoList *RepoList, ignoreRepoList []Repo) map[string]*Providers {
+	sources := map[string]*Providers{}
+
+	for _, repo := range *repoList {
+		if ignoreThisRepo(repo.URL, ignoreRepoList) {
+			continue
+		}
+		host, _, _ := DecomposeGitURL(repo.URL)
+		if _, found := sources[host]; found {
+			continue
+		}
+
+		var source *Providers
+		switch hostProvider(host) {
+		case "github":
+			// GitHub App environment belongs to the mirror owner, source hosts use tokens
+			source = &Providers{Github: &github.GitGetGithub{}}
+			source.Github.InitTokenForHost(host)
+			source.Github.SetCABundle(githubCABundle)
+		case "gitlab":
+			source = &Providers{Gitlab: &gitlab.GitGetGitlab{}}
+			source.Gitlab.InitForHost(host)
+		case "bitbucket":
+			if host == bitbucketCloudHost {
+				source = &Providers{Bitbucket: &bitbucket.GitGetBitbucket{}}
+				source.Bitbucket.InitForHost(host)
+			}
+		}
+		if source == nil {
+			log.Warnf("Metadata of '%s' repositories won't be synchronised, provider is not supported", host)
+		}
+		sources[host] = source
+	}
+
+	return sources
+}
+
+// splitFullName - splits repository full name into owner and repository name ( a/b -> a, b )
+func splitFullName(fullName string) (owner, repository string) {
+	nameParts := strings.SplitN(fullName, "/", 2)
+	if len(nameParts) < 2 {
+		return "", fullName
+	}
+
+	return nameParts[0], nameParts[1]
+}
+
+// repositoryMetadata - returns metadata of repository `fullName` on `baseURL` host
+func (providers *Providers) repositoryMetadata(repoSha, baseURL, fullName string) (metadata.Repository, error) {
+	owner, repository := splitFullName(fullName)
+
+	switch {
+	case providers.Github != nil:
+		return providers.Github.GetRepositoryMetadata(context.Background(), repoSha, baseURL, owner, repository)
+	case providers.Gitlab != nil:
+		return providers.Gitlab.GetProjectMetadata(repoSha, baseURL, fullName)
+	case providers.Bitbucket != nil:
+		return providers.Bitbucket.GetRepositoryMetadata(repoSha, owner, repository)
+	default:
+		return metadata.Repository{}, errors.New("metadata synchronisation is not supported by the provider")
+	}
+}
+
+// updateRepositoryMetadata - updates metadata of repository `fullName` on `baseURL` host
+func (providers *Providers) updateRepositoryMetadata(
+	repoSha, baseURL, fullName string,
+	current, desired metadata.Repository,
+) error {
+	owner, repository := splitFullName(fullName)
+
+	switch {
+	case providers.Github != nil:
+		return providers.Github.UpdateRepositoryMetadata(
+			context.Background(), repoSha, baseURL, owner, repository, current, desired)
+	case providers.Gitlab != nil:
+		return providers.Gitlab.UpdateProjectMetadata(repoSha, baseURL, fullName, current, desired)
+	case providers.Bitbucket != nil:
+		return providers.Bitbucket.UpdateRepositoryMetadata(repoSha, owner, repository, current, desired)
+	default:
+		return errors.New("metadata synchronisation is not supported by the provider")
+	}
+}
+
+// SyncMirrorMetadata - updates mirror repository description, homepage, topics, default branch and
+// archived state from the source repository, default branch follows configured `ref` if it is set
+// and visibility follows mirror visibility mode, failures don't stop mirroring
+func (repo *Repo) SyncMirrorMetadata() {
+	sourceHost, sourceFullName, _ := DecomposeGitURL(repo.URL)
+	source := repo.providers.Sources[sourceHost]
+	if source == nil {
+		log.Debugf("%s: skipping metadata synchronisation of '%s'", repo.sha, repo.URL)
+		return
+	}
+
+	desired, err := source.repositoryMetadata(repo.sha, sourceHost, sourceFullName)
+	if err != nil {
+		log.Warnf("%s: Unable to read metadata of '%s': %s", repo.sha, repo.URL, err)
+		return
+	}
+	if !repo.defaultRef {
+		desired.DefaultBranch = repo.Ref
+	}
+	desired.Visibility = mirrorVisibilityMode
+
+	mirrorHost, mirrorFullName, _ := DecomposeGitURL(repo.mirrorURL)
+	current, err := repo.providers.repositoryMetadata(repo.sha, mirrorHost, mirrorFullName)
+	if err != nil {
+		log.Warnf("%s: Unable to read metadata of mirror '%s': %s", repo.sha, repo.mirrorURL, err)
+		return
+	}
+
+	log.Debugf("%s: Synchronising metadata '%+v' of mirror '%s'", repo.sha, desired, repo.mirrorURL)
+	err = repo.providers.updateRepositoryMetadata(repo.sha, mirrorHost, mirrorFullName, current, desired)
+	if err != nil {
+		log.Warnf("%s: Unable to update metadata of mirror '%s': %s", repo.sha, repo.mirrorURL, err)
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_splitFullName(t *testing.T) {
+	owner, repository := splitFullName("acme/api")
+	assert.Equal(t, "acme", owner)
+	assert.Equal(t, "api", repository)
+
+	owner, repository = splitFullName("api")
+	assert.Equal(t, "", owner)
+	assert.Equal(t, "api", repository)
+}
+
+func Test_newSourceProviders(t *testing.T) {
+	resetAuthHeaderCache(t)
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+	t.Setenv("GITLAB_TOKEN", "gl-token")
+	// source GitHub client doesn't use GitHub App of the mirror owner
+	t.Setenv("GITHUB_APP_ID", "12345")
+	setHostProvider("git@gitlab.acme.com:acme/mirrors", "gitlab", nil)
+
+	repoList := RepoList{
+		{URL: "git@github.com:acme/api.git"},
+		{URL: "https://github.com/acme/web.git"},
+		{URL: "git@gitlab.acme.com:acme/a/api.git"},
+		{URL: "git@gitlab.corp.example:acme/api.git"},
+		{URL: "ssh://git@bitbucket.acme.com:7999/acme/api.git"},
+		{URL: "git@git.acme.com:acme/ignored.git"},
+	}
+	ignoreRepoList := []Repo{{URL: "git@git.acme.com:acme/ignored.git"}}
+
+	sources := newSourceProviders(&repoList, ignoreRepoList)
+
+	assert.Len(t, sources, 4)
+	assert.NotNil(t, sources["github.com"].Github)
+	token, err := sources["github.com"].Github.Token(context.Background(), "sha", "github.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "gh-token", token)
+	assert.Nil(t, sources["gitlab.corp.example"])
+	assert.NotNil(t, sources["gitlab.acme.com"].Gitlab)
+	assert.Nil(t, sources["bitbucket.acme.com"])
+}
@@ -0,0 +1,251 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/isindir/git-get/gitlab"
+)
+
+// Mirror naming strategies
+const (
+	MirrorNamingFlat         = "flat"           // <mirror-url>/<name>
+	MirrorNamingNamespace    = "namespace"      // <mirror-url>/<source namespace without owner>/<name>
+	MirrorNamingFullPath     = "full-path"      // <mirror-url>/<source owner>/<source namespace>/<name>
+	MirrorNamingFullPathDash = "full-path-dash" // <mirror-url>/<source owner>-<source namespace>-<name>
+	MirrorNamingTemplate     = "template"       // <mirror-url>/<rendered mirror naming template>
+)
+
+// MirrorNameFields - source repository fields available in mirror naming template
+type MirrorNameFields struct {
+	Host      string // source host ( gitlab.com )
+	Owner     string // top-level owner ( src )
+	Namespace string // namespace without owner ( a/b )
+	Name      string // source repository name ( repo )
+	AltName   string // repository name from configuration or source repository name
+	FullPath  string // source repository full path ( src/a/b/repo )
+}
+
+// sourceNameFields - decomposes source repository URL into naming template fields
+// ( git@gitlab.com:src/a/b/repo.git -> gitlab.com, src, a/b, repo )
+func sourceNameFields(repoURL, altName string) MirrorNameFields {
+	host, fullName, shortName := DecomposeGitURL(repoURL)
+	pathElements := strings.Split(fullName, "/")
+	fields := MirrorNameFields{
+		Host:     host,
+		Name:     shortName,
+		AltName:  altName,
+		FullPath: fullName,
+	}
+	if len(pathElements) > 1 {
+		fields.Owner = pathElements[0]
+	}
+	if len(pathElements) > 2 {
+		fields.Namespace = strings.Join(pathElements[1:len(pathElements)-1], "/")
+	}
+
+	return fields
+}
+
+// sourceNamespace - returns namespace of the source repository without its top-level owner
+// ( git@gitlab.com:src/a/b/repo.git -> a/b )
+func sourceNamespace(repoURL string) string {
+	return sourceNameFields(repoURL, "").Namespace
+}
+
+// mirrorName - returns mirror repository name relative to mirror root URL according to naming strategy
+func (repo *Repo) mirrorName() string {
+	if mirrorNaming == MirrorNamingFlat {
+		return repo.AltName
+	}
+	fields := sourceNameFields(repo.URL, repo.AltName)
+
+	switch mirrorNaming {
+	case MirrorNamingNamespace:
+		return path.Join(fields.Namespace, repo.AltName)
+	case MirrorNamingFullPath:
+		return path.Join(fields.Owner, fields.Namespace, repo.AltName)
+	case MirrorNamingFullPathDash:
+		return strings.ReplaceAll(path.Join(fields.Owner, fields.Namespace, repo.AltName), "/", "-")
+	case MirrorNamingTemplate:
+		var name bytes.Buffer
+		if err := mirrorNameTemplate.Execute(&name, fields); err != nil {
+			log.Fatalf("%s: Error - while rendering mirror naming template: %s", repo.sha, err)
+			os.Exit(1)
+		}
+		return strings.TrimSuffix(strings.Trim(strings.TrimSpace(name.String()), "/"), ".git")
+	default:
+		return repo.AltName
+	}
+}
+
+// mirrorPath - returns mirror repository path relative to mirror root URL, Gitlab group and project
+// paths are slugs of the mirror name elements
+func (repo *Repo) mirrorPath() string {
+	name := repo.mirrorName()
+	if gitProvider != "gitlab" {
+		return name
+	}
+
+	pathElements := strings.Split(name, "/")
+	for element := range pathElements {
+		pathElements[element] = gitlab.PathSlug(pathElements[element])
+	}
+
+	return strings.Join(pathElements, "/")
+}
+
+// validMirrorPath - returns false if any element of mirror repository path is empty, Gitlab path slug
+// of names without ASCII letters or digits is empty
+func (repo *Repo) validMirrorPath() bool {
+	for _, pathElement := range strings.Split(repo.mirrorPath(), "/") {
+		if pathElement == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateMirrorNaming - ensures mirror naming strategy is known and supported by the mirror provider,
+// parses naming template for the template strategy
+func validateMirrorNaming(namingTemplate string) {
+	switch mirrorNaming {
+	case MirrorNamingFlat, MirrorNamingFullPathDash:
+	case MirrorNamingNamespace, MirrorNamingFullPath:
+		if gitProvider != "gitlab" {
+			log.Fatalf("Error: '%s' mirror naming is only supported by 'gitlab' mirror provider", mirrorNaming)
+			os.Exit(1)
+		}
+	case MirrorNamingTemplate:
+		if namingTemplate == "" {
+			log.Fatalf("Error: '%s' mirror naming requires mirror naming template", mirrorNaming)
+			os.Exit(1)
+		}
+		parsedTemplate, err := template.New("mirror-naming").Option("missingkey=error").Parse(namingTemplate)
+		if err != nil {
+			log.Fatalf("Error: %s, while parsing mirror naming template", err)
+			os.Exit(1)
+		}
+		mirrorNameTemplate = parsedTemplate
+	default:
+		log.Fatalf("Error: unknown '%s' mirror naming", mirrorNaming)
+		os.Exit(1)
+	}
+}
+
+// validateGitlabNamespaceID - ensures mirror namespace ID is only used with Gitlab mirror provider
+func validateGitlabNamespaceID() {
+	if gitlabMirrorNamespaceID != 0 && gitProvider != "gitlab" {
+		log.Fatalf("Error: mirror namespace ID is only supported by 'gitlab' mirror provider")
+		os.Exit(1)
+	}
+}
+
+// gitlabNamespaceRootURL - returns mirror root URL with the path of Gitlab group or user namespace
+// identified by ID, so mirrors follow the namespace after it is renamed or transferred
+// ( git@gitlab.com:acme/mirrors + namespace 42 at acme/dr/mirrors -> git@gitlab.com:acme/dr/mirrors )
+func gitlabNamespaceRootURL(gitlabObj *gitlab.GitGetGitlab, mirrorRootURL string) string {
+	rootURL := strings.TrimSuffix(mirrorRootURL, "/")
+	baseURL, rootPath, _ := DecomposeGitURL(rootURL)
+	namespacePath := gitlabObj.GetNamespaceFullPath("", baseURL, gitlabMirrorNamespaceID)
+	if strings.EqualFold(rootPath, namespacePath) {
+		return rootURL
+	}
+
+	log.Warnf(
+		"Gitlab namespace '%d' path is '%s', mirroring to it instead of '%s'",
+		gitlabMirrorNamespaceID, namespacePath, rootPath)
+	return strings.TrimSuffix(rootURL, rootPath) + namespacePath
+}
+
+// mirrorNameCollisions - returns source repository URLs grouped by mirror URL, for mirror URLs
+// shared by more than one source repository, mirror URLs are compared case-insensitively
+// as git providers do not allow repositories which names differ only by case
+func mirrorNameCollisions(repoList *RepoList, ignoreRepoList []Repo, mirrorRootURL string) map[string][]string {
+	sources := map[string][]string{}
+
+	for _, configRepo := range *repoList {
+		if ignoreThisRepo(configRepo.URL, ignoreRepoList) {
+			continue
+		}
+		repo := configRepo
+		repo.SetRepoLocalName()
+		repo.SetMirrorURL(mirrorRootURL)
+
+		if gitProvider != "gitlab" && strings.Contains(repo.mirrorName(), "/") {
+			log.Fatalf(
+				"Error: mirror name '%s' of '%s' contains '/', nested names are only supported by 'gitlab' mirror provider",
+				repo.mirrorName(), repo.URL)
+			os.Exit(1)
+		}
+		if !repo.validMirrorPath() {
+			log.Fatalf(
+				"Error: mirror name '%s' of '%s' has no valid mirror path, use 'altname' in configuration",
+				repo.mirrorName(), repo.URL)
+			os.Exit(1)
+		}
+
+		key := strings.ToLower(repo.mirrorURL)
+		sources[key] = append(sources[key], repo.URL)
+	}
+
+	for mirrorURL, sourceURLs := range sources {
+		if len(sourceURLs) < 2 {
+			delete(sources, mirrorURL)
+		}
+	}
+
+	return sources
+}
+
+// checkMirrorNames - fails before any repository is mirrored if mirror path of a source repository
+// is invalid or several source repositories would be pushed to the same mirror repository
+func checkMirrorNames(repoList *RepoList, ignoreRepoList []Repo, mirrorRootURL string) {
+	collisions := mirrorNameCollisions(repoList, ignoreRepoList, mirrorRootURL)
+	if len(collisions) == 0 {
+		return
+	}
+
+	mirrorURLs := make([]string, 0, len(collisions))
+	for mirrorURL := range collisions {
+		mirrorURLs = append(mirrorURLs, mirrorURL)
+	}
+	sort.Strings(mirrorURLs)
+
+	for _, mirrorURL := range mirrorURLs {
+		log.Errorf("Mirror '%s' collides for: %s", mirrorURL, strings.Join(collisions[mirrorURL], ", "))
+	}
+	log.Fatalf(
+		"Error: %d mirror name collision(s) found, use different '--mirror-naming' or 'altname' in configuration",
+		len(collisions))
+	os.Exit(1)
+}
@@ -0,0 +1,115 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setMirrorNaming(t *testing.T, provider, strategy, namingTemplate string) {
+	originalProvider := gitProvider
+	t.Cleanup(func() {
+		gitProvider = originalProvider
+		mirrorNaming = MirrorNamingFlat
+		mirrorNameTemplate = nil
+	})
+	gitProvider = provider
+	mirrorNaming = strategy
+	validateMirrorNaming(namingTemplate)
+}
+
+func Test_sourceNameFields(t *testing.T) {
+	assert.Equal(t, MirrorNameFields{
+		Host:      "gitlab.com",
+		Owner:     "src",
+		Namespace: "a/b",
+		Name:      "repo",
+		AltName:   "alt",
+		FullPath:  "src/a/b/repo",
+	}, sourceNameFields("git@gitlab.com:src/a/b/repo.git", "alt"))
+
+	assert.Equal(t, "", sourceNamespace("https://github.com/src/repo.git"))
+	assert.Equal(t, "a", sourceNamespace("ssh://git@bitbucket.acme.com:7999/src/a/repo.git"))
+}
+
+func Test_SetMirrorURL_Naming(t *testing.T) {
+	testCases := []struct {
+		name           string
+		strategy       string
+		namingTemplate string
+		repo           Repo
+		expectedResult string
+	}{
+		{name: "flat", strategy: MirrorNamingFlat, repo: Repo{URL: "git@gitlab.com:src/a/b/repo.git", AltName: "repo"}, expectedResult: "git@gitlab.com:acme/mirrors/repo.git"},
+		{name: "namespace owner only", strategy: MirrorNamingNamespace, repo: Repo{URL: "git@gitlab.com:src/repo.git", AltName: "repo"}, expectedResult: "git@gitlab.com:acme/mirrors/repo.git"},
+		{name: "namespace subgroups", strategy: MirrorNamingNamespace, repo: Repo{URL: "git@gitlab.com:src/a/b/repo.git", AltName: "repo"}, expectedResult: "git@gitlab.com:acme/mirrors/a/b/repo.git"},
+		{name: "namespace https altname", strategy: MirrorNamingNamespace, repo: Repo{URL: "https://github.com/src/a/repo.git", AltName: "alt"}, expectedResult: "git@gitlab.com:acme/mirrors/a/alt.git"},
+		{name: "full path", strategy: MirrorNamingFullPath, repo: Repo{URL: "git@gitlab.com:src/a/b/repo.git", AltName: "repo"}, expectedResult: "git@gitlab.com:acme/mirrors/src/a/b/repo.git"},
+		{name: "full path dash", strategy: MirrorNamingFullPathDash, repo: Repo{URL: "git@gitlab.com:src/a/b/repo.git", AltName: "alt"}, expectedResult: "git@gitlab.com:acme/mirrors/src-a-b-alt.git"},
+		{name: "flat slug", strategy: MirrorNamingFlat, repo: Repo{URL: "git@gitlab.com:src/repo.git", AltName: "My Repo"}, expectedResult: "git@gitlab.com:acme/mirrors/my-repo.git"},
+		{name: "template slug", strategy: MirrorNamingTemplate, namingTemplate: "{{.Owner}} Team/{{.Name}}", repo: Repo{URL: "https://github.com/Src/Repo.git", AltName: "alt"}, expectedResult: "git@gitlab.com:acme/mirrors/src-team/repo.git"},
+		{name: "template", strategy: MirrorNamingTemplate, namingTemplate: "{{.Host}}/{{.Owner}}_{{.Name}}.git", repo: Repo{URL: "https://github.com/src/repo.git", AltName: "alt"}, expectedResult: "git@gitlab.com:acme/mirrors/github.com/src_repo.git"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setMirrorNaming(t, "gitlab", tc.strategy, tc.namingTemplate)
+			tc.repo.SetMirrorURL("git@gitlab.com:acme/mirrors")
+			assert.Equal(t, tc.expectedResult, tc.repo.mirrorURL)
+		})
+	}
+}
+
+func Test_Repo_validMirrorPath(t *testing.T) {
+	setMirrorNaming(t, "gitlab", MirrorNamingTemplate, "{{.Owner}}/{{.AltName}}")
+
+	assert.True(t, (&Repo{URL: "git@gitlab.com:src/repo.git", AltName: "My Repo"}).validMirrorPath())
+	assert.False(t, (&Repo{URL: "git@gitlab.com:src/repo.git", AltName: "Проект"}).validMirrorPath())
+	assert.False(t, (&Repo{URL: "git@gitlab.com:src/repo.git", AltName: "---"}).validMirrorPath())
+
+	gitProvider = "github"
+	assert.True(t, (&Repo{URL: "git@gitlab.com:src/repo.git", AltName: "Проект"}).validMirrorPath())
+}
+
+func Test_mirrorNameCollisions(t *testing.T) {
+	repoList := RepoList{
+		{URL: "git@github.com:acme/api.git"},
+		{URL: "git@github.com:other/api.git"},
+		{URL: "git@github.com:other/API.git"},
+		{URL: "git@github.com:third/api.git"},
+		{URL: "git@github.com:acme/web.git"},
+		{URL: "git@github.com:other/web.git", AltName: "other-web"},
+	}
+	ignoreRepoList := []Repo{{URL: "git@github.com:third/api.git"}}
+
+	setMirrorNaming(t, "github", MirrorNamingFlat, "")
+	assert.Equal(t, map[string][]string{
+		"git@github.com:mirrors/api.git": {
+			"git@github.com:acme/api.git",
+			"git@github.com:other/api.git",
+			"git@github.com:other/API.git",
+		},
+	}, mirrorNameCollisions(&repoList, ignoreRepoList, "git@github.com:mirrors"))
+
+	setMirrorNaming(t, "github", MirrorNamingFullPathDash, "")
+	assert.Equal(t, map[string][]string{
+		"git@github.com:mirrors/other-api.git": {
+			"git@github.com:other/api.git",
+			"git@github.com:other/API.git",
+		},
+	}, mirrorNameCollisions(&repoList, ignoreRepoList, "git@github.com:mirrors"))
+}
+
+func Test_Repo_mirrorPath(t *testing.T) {
+	repo := Repo{URL: "https://github.com/src/repo.git", AltName: "My_Repo"}
+
+	setMirrorNaming(t, "github", MirrorNamingFlat, "")
+	assert.Equal(t, "My_Repo", repo.mirrorPath())
+
+	setMirrorNaming(t, "gitlab", MirrorNamingFlat, "")
+	assert.Equal(t, "my_repo", repo.mirrorPath())
+	assert.Equal(t, "My_Repo", repo.mirrorName())
+}
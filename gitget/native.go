@@ -0,0 +1,144 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Mirror modes
+const (
+	MirrorModePush   = "push"   // clone source repository and push it to the mirror
+	MirrorModeNative = "native" // configure mirror provider to pull source repository on its side
+)
+
+// validateMirrorMode - ensures mirror mode is known and supported by the mirror provider
+func validateMirrorMode() {
+	switch mirrorMode {
+	case MirrorModePush:
+	case MirrorModeNative:
+		if gitProvider != "gitlab" {
+			log.Fatalf("Error: '%s' mirror mode is only supported by 'gitlab' mirror provider", mirrorMode)
+			os.Exit(1)
+		}
+		if mirrorCacheDir != "" {
+			log.Fatalf("Error: mirror cache directory can't be used with '%s' mirror mode", mirrorMode)
+			os.Exit(1)
+		}
+	default:
+		log.Fatalf("Error: unknown '%s' mirror mode", mirrorMode)
+		os.Exit(1)
+	}
+}
+
+// httpsSourceURL - returns source repository URL for the mirror provider to pull, providers pull mirrors
+// over https and ssh URLs can't be reliably mapped to https ones ( Bitbucket Server serves repositories
+// under '/scm/' on a different port ), so only https URLs are accepted
+func httpsSourceURL(repoURL string) (string, error) {
+	parsedURL, err := url.Parse(repoURL)
+	if err != nil || (parsedURL.Scheme != HTTPS && parsedURL.Scheme != "http") {
+		return "", fmt.Errorf("source repository URL '%s' must be https in '%s' mirror mode", repoURL, MirrorModeNative)
+	}
+
+	return repoURL, nil
+}
+
+// nativeSourceURLErrors - returns errors of source repository URLs the mirror provider can't pull
+func nativeSourceURLErrors(repoList *RepoList, ignoreRepoList []Repo) []error {
+	var errs []error
+
+	for _, repo := range *repoList {
+		if ignoreThisRepo(repo.URL, ignoreRepoList) {
+			continue
+		}
+		if _, err := httpsSourceURL(repo.URL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// checkNativeSourceURLs - fails before any native mirror is configured if some source repository URL
+// can't be pulled by the mirror provider, so the run doesn't stop with part of mirrors configured
+func checkNativeSourceURLs(repoList *RepoList, ignoreRepoList []Repo) {
+	errs := nativeSourceURLErrors(repoList, ignoreRepoList)
+	if len(errs) == 0 {
+		return
+	}
+
+	for _, err := range errs {
+		log.Errorf("%s", err)
+	}
+	log.Fatalf("Error: %d source repository URL(s) can't be used in '%s' mirror mode", len(errs), MirrorModeNative)
+	os.Exit(1)
+}
+
+// sourceCredentials - returns credentials of the source repository host for the mirror provider to pull
+// private repositories, credentials are only passed to the mirror provider when https token
+// authentication is enabled
+func sourceCredentials(repoSha, sourceURL string) (username, token string) {
+	if !httpsTokenAuth {
+		return "", ""
+	}
+	parsedURL, err := url.Parse(sourceURL)
+	if err != nil {
+		return "", ""
+	}
+
+	host := parsedURL.Hostname()
+	username, token, err = hostToken(host)
+	if err != nil {
+		log.Warnf("%s: No token for '%s', mirror will pull source without authentication: %s", repoSha, host, err)
+		return "", ""
+	}
+
+	return username, token
+}
+
+// MirrorNative - creates mirror repository and configures it to pull the source repository, no git
+// operations are performed locally
+func (repo *Repo) MirrorNative(pushMirror bool) {
+	if !pushMirror {
+		log.Infof("%s: skipping '%s' native mirror configuration per user request", repo.sha, repo.URL)
+		return
+	}
+
+	// source URLs are validated by checkNativeSourceURLs before any mirror is configured
+	sourceURL, err := httpsSourceURL(repo.URL)
+	if err != nil {
+		log.Errorf("%s: Error - %s", repo.sha, err)
+		repo.mirrorFailed(err, "")
+		return
+	}
+
+	repo.EnsureMirrorExists()
+
+	baseURL, projectNameFullPath, _ := DecomposeGitURL(repo.mirrorURL)
+	username, token := sourceCredentials(repo.sha, sourceURL)
+	repo.providers.Gitlab.ConfigurePullMirror(repo.sha, baseURL, projectNameFullPath, sourceURL, username, token)
+}
@@ -0,0 +1,52 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_httpsSourceURL(t *testing.T) {
+	sourceURL, err := httpsSourceURL("https://github.com/acme/api.git")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/api.git", sourceURL)
+
+	for _, repoURL := range []string{"git@gitlab.com:acme/a/api.git", "ssh://git@bitbucket.acme.com:7999/src/api.git"} {
+		t.Run(repoURL, func(t *testing.T) {
+			_, err := httpsSourceURL(repoURL)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_nativeSourceURLErrors(t *testing.T) {
+	repoList := &RepoList{
+		{URL: "https://github.com/acme/api.git"},
+		{URL: "git@gitlab.com:acme/a/api.git"},
+		{URL: "ssh://git@bitbucket.acme.com:7999/src/api.git"},
+		{URL: "git@github.com:acme/ignored.git"},
+	}
+	ignoreRepoList := []Repo{{URL: "git@github.com:acme/ignored.git"}}
+
+	errs := nativeSourceURLErrors(repoList, ignoreRepoList)
+	assert.Len(t, errs, 2)
+	assert.Empty(t, nativeSourceURLErrors(&RepoList{{URL: "https://github.com/acme/api.git"}}, nil))
+}
+
+func Test_sourceCredentials(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+	defer func() { httpsTokenAuth = false }()
+
+	httpsTokenAuth = false
+	username, token := sourceCredentials("sha", "https://github.com/acme/api.git")
+	assert.Equal(t, "", username)
+	assert.Equal(t, "", token)
+
+	httpsTokenAuth = true
+	username, token = sourceCredentials("sha", "https://github.com/acme/api.git")
+	assert.Equal(t, "x-access-token", username)
+	assert.Equal(t, "gh-token", token)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/isindir/git-get/bitbucket"
+	"github.com/isindir/git-get/bitbucketserver"
+	"github.com/isindir/git-get/github"
+	"github.com/isindir/git-get/gitlab"
+	"github.com/isindir/git-get/transport"
+)
+
+// Providers - git provider API clients created once per run and shared by all repositories,
+// only the client of the provider selected for the run is set
+type Providers struct {
+	Github          *github.GitGetGithub
+	Gitlab          *gitlab.GitGetGitlab
+	Bitbucket       *bitbucket.GitGetBitbucket
+	BitbucketServer *bitbucketserver.GitGetBitbucketServer
+	// source repositories provider API clients by host, only set when mirror metadata is synchronised
+	Sources map[string]*Providers
+}
+
+// NewProviders - creates API client of the provider, reading credentials for the host of `rootURL`
+func NewProviders(providerName, rootURL string) *Providers {
+	host := gitURLHost(rootURL)
+	providers := &Providers{}
+
+	switch providerName {
+	case "github":
+		providers.Github = &github.GitGetGithub{}
+		providers.Github.InitForHost(host)
+		providers.Github.SetCABundle(githubCABundle)
+	case "gitlab":
+		providers.Gitlab = &gitlab.GitGetGitlab{}
+		providers.Gitlab.InitForHost(host)
+	case "bitbucket":
+		if bitbucketServerURL != "" {
+			providers.BitbucketServer = &bitbucketserver.GitGetBitbucketServer{}
+			providers.BitbucketServer.InitForHost(host)
+		} else {
+			providers.Bitbucket = &bitbucket.GitGetBitbucket{}
+			providers.Bitbucket.InitForHost(host)
+		}
+	default:
+		log.Fatalf("Error: unknown '%s' git provider", providerName)
+		os.Exit(1)
+	}
+	setHostProvider(rootURL, providerName, providers.Github)
+
+	return providers
+}
+
+// SetProviders - sets provider API clients used by the repository operations
+func (repo *Repo) SetProviders(providers *Providers) {
+	repo.providers = providers
+}
+
+// logAPICalls - reports number of provider API calls made during the run
+func logAPICalls() {
+	for _, hostCount := range transport.Counts() {
+		log.Infof("API calls to '%s': %d", hostCount.Host, hostCount.Calls)
+	}
+	log.Infof("Total API calls: %d", transport.Total())
+}
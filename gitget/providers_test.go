@@ -0,0 +1,30 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewProviders(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "test-gitlab-token")
+	providers := NewProviders("gitlab", "git@gitlab.acme.com:acme/mirrors")
+	assert.NotNil(t, providers.Gitlab)
+	assert.Nil(t, providers.Github)
+	assert.Nil(t, providers.Bitbucket)
+	assert.Nil(t, providers.BitbucketServer)
+
+	t.Setenv("BITBUCKET_TOKEN", "test-bitbucket-token")
+	bitbucketServerURL = "https://bitbucket.acme.com"
+	defer func() { bitbucketServerURL = "" }()
+	providers = NewProviders("bitbucket", "ssh://git@bitbucket.acme.com:7999/MIRRORS")
+	assert.NotNil(t, providers.BitbucketServer)
+	assert.Nil(t, providers.Bitbucket)
+
+	repo := Repo{}
+	repo.SetProviders(providers)
+	assert.Same(t, providers, repo.providers)
+}
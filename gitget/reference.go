@@ -0,0 +1,99 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// referenceLockSuffix - suffix of the lock file next to the reference cache repository
+const referenceLockSuffix = ".lock"
+
+// lockReferenceCache - takes exclusive lock of the reference cache repository, which is shared by
+// concurrent git-get processes and goroutines, returns the locked lock file
+func lockReferenceCache(cachePath string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(cachePath+referenceLockSuffix, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// unlockReferenceCache - returns function releasing lock of the reference cache repository
+func unlockReferenceCache(file *os.File) func() {
+	return func() {
+		if err := unlockFile(file); err != nil {
+			log.Warnf("Unable to unlock '%s': %v", file.Name(), err)
+		}
+		file.Close()
+	}
+}
+
+// PrepareReferenceCache - creates or updates bare mirror of the repository in reference cache, so
+// that clone borrows objects from it and fetches only missing objects from the remote, returns
+// function releasing reference cache lock, which must be held until clone finishes. Cache is locked
+// exclusively only while it is updated, clones from the cache share the lock. Clone falls back to
+// the remote only if reference cache can't be used
+func (repo *Repo) PrepareReferenceCache() func() {
+	repo.referencePath = ""
+	if referenceCacheDir == "" {
+		return func() {}
+	}
+
+	cache := Repo{URL: repo.URL, sha: repo.sha, executor: repo.executor}
+	cache.SetMirrorCachePath(referenceCacheDir)
+
+	file, err := lockReferenceCache(cache.fullPath)
+	if err != nil {
+		log.Warnf("%s: Unable to lock reference cache '%s', cloning without it: %v", repo.sha, cache.fullPath, err)
+		return func() {}
+	}
+	unlock := unlockReferenceCache(file)
+
+	if !cache.SyncMirrorCache(false) {
+		log.Warnf("%s: Unable to update reference cache '%s', cloning without it", repo.sha, cache.fullPath)
+		unlock()
+		return func() {}
+	}
+
+	if err := shareFileLock(file); err != nil {
+		log.Warnf("%s: Unable to share reference cache '%s' lock, holding it exclusively: %v",
+			repo.sha, cache.fullPath, err)
+	}
+
+	repo.referencePath = cache.fullPath
+	return unlock
+}
@@ -0,0 +1,87 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_lockReferenceCache(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "github.com", "acme", "api.git")
+
+	file, err := lockReferenceCache(cachePath)
+	require.NoError(t, err)
+	assert.FileExists(t, cachePath+referenceLockSuffix)
+
+	locked := make(chan *os.File)
+	go func() {
+		secondFile, err := lockReferenceCache(cachePath)
+		assert.NoError(t, err)
+		locked <- secondFile
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("reference cache was locked twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// shared lock still keeps cache updates out
+	require.NoError(t, shareFileLock(file))
+	select {
+	case <-locked:
+		t.Fatal("reference cache was locked while it is shared")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlockReferenceCache(file)()
+	select {
+	case secondFile := <-locked:
+		unlockReferenceCache(secondFile)()
+	case <-time.After(5 * time.Second):
+		t.Fatal("reference cache lock was not released")
+	}
+}
+
+func Test_Repo_Clone_ReferenceCache(t *testing.T) {
+	sourceDir, commit := exportSource(t)
+	cacheDir := t.TempDir()
+	referenceCacheDir = cacheDir
+	defer func() { referenceCacheDir = "" }()
+
+	repo := Repo{URL: "file://" + sourceDir, Ref: "main", fullPath: filepath.Join(t.TempDir(), "clone")}
+	repo.SetShellRunner(shellRunner)
+	cachePath := repo.cachePath(cacheDir)
+
+	require.True(t, repo.Clone())
+	assert.Equal(t, cachePath, repo.referencePath)
+	assert.DirExists(t, cachePath)
+	assert.FileExists(t, filepath.Join(repo.fullPath, "a", "file"))
+	// clone doesn't depend on reference cache
+	assert.NoFileExists(t, filepath.Join(repo.fullPath, ".git", "objects", "info", "alternates"))
+
+	// reference cache is updated before the next clone
+	gitRun(t, sourceDir, "commit", "-q", "--allow-empty", "-m", "second")
+	gitRun(t, sourceDir, "branch", "feature", commit)
+	clone := Repo{URL: repo.URL, Ref: "feature", fullPath: filepath.Join(t.TempDir(), "clone")}
+	clone.SetShellRunner(shellRunner)
+	require.True(t, clone.ShallowClone())
+	refs, err := (&Repo{fullPath: cachePath, executor: repo.executor}).mirrorRefs()
+	require.NoError(t, err)
+	assert.Contains(t, refs, "refs/heads/feature")
+
+	// clone falls back to remote when reference cache can't be updated
+	require.NoError(t, os.RemoveAll(cachePath))
+	require.NoError(t, os.WriteFile(cachePath, []byte("not a repository"), 0o644))
+	fallback := Repo{URL: repo.URL, Ref: "main", fullPath: filepath.Join(t.TempDir(), "clone")}
+	fallback.SetShellRunner(shellRunner)
+	assert.True(t, fallback.Clone())
+	assert.Empty(t, fallback.referencePath)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"strings"
+)
+
+// mirrorRefFilters - returns refs patterns to push and to exclude for the repository, repository
+// refs patterns override per run ones, exclude patterns of both are used
+func (repo *Repo) mirrorRefFilters() (includeRefs, excludeRefs []string, noDelete bool) {
+	includeRefs = mirrorRefs
+	if len(repo.MirrorRefs) > 0 {
+		includeRefs = repo.MirrorRefs
+	}
+	excludeRefs = append(append([]string{}, mirrorExcludeRefs...), repo.MirrorExcludeRefs...)
+
+	return includeRefs, excludeRefs, mirrorNoDelete || repo.MirrorNoDelete
+}
+
+// mirrorPushArgs - returns `git push` arguments, without ref filtering all refs are pushed with
+// `--mirror`, otherwise refspecs are built from patterns, exclude patterns become negative refspecs
+// and mirror refs missing in source are deleted with `--prune` unless no-delete mode is used, push
+// progress is requested to report size of objects written to mirror
+func (repo *Repo) mirrorPushArgs() []string {
+	includeRefs, excludeRefs, noDelete := repo.mirrorRefFilters()
+	if len(includeRefs) == 0 && len(excludeRefs) == 0 && !noDelete {
+		return []string{"push", "--porcelain", "--progress", "--mirror", repo.mirrorURL}
+	}
+
+	args := []string{"push", "--porcelain", "--progress", "--force"}
+	if !noDelete {
+		args = append(args, "--prune")
+	}
+	args = append(args, repo.mirrorURL)
+
+	if len(includeRefs) == 0 {
+		includeRefs = []string{"refs/*"}
+	}
+	for _, includeRef := range includeRefs {
+		args = append(args, "+"+includeRef+":"+includeRef)
+	}
+	for _, excludeRef := range excludeRefs {
+		args = append(args, "^"+excludeRef)
+	}
+
+	return args
+}
+
+// hiddenRefNamespaces - namespaces of provider managed refs, which mirrors hide and reject on push
+var hiddenRefNamespaces = []string{"refs/pull/", "refs/merge-requests/"}
+
+// onlyHiddenRefsRejected - returns true if refs were rejected and all of them are provider managed
+// hidden refs, rejections parsed by parsePushRejections start with the ref name
+func onlyHiddenRefsRejected(rejectedRefs []string) bool {
+	for _, rejectedRef := range rejectedRefs {
+		hidden := false
+		for _, namespace := range hiddenRefNamespaces {
+			hidden = hidden || strings.HasPrefix(rejectedRef, namespace)
+		}
+		if !hidden {
+			return false
+		}
+	}
+
+	return len(rejectedRefs) > 0
+}
+
+// parsePushRejections - returns refs rejected by remote with reasons from `git push --porcelain` output
+// ( "!\trefs/pull/1/head:refs/pull/1/head\t[remote rejected] (deny updating a hidden ref)" )
+func parsePushRejections(pushOutput string) []string {
+	var rejectedRefs []string
+	for _, line := range strings.Split(pushOutput, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 || fields[0] != "!" {
+			continue
+		}
+		ref := fields[1]
+		if refParts := strings.SplitN(ref, ":", 2); len(refParts) == 2 && refParts[1] != "" {
+			ref = refParts[1]
+		}
+		rejectedRefs = append(rejectedRefs, ref+" "+fields[2])
+	}
+
+	return rejectedRefs
+}
@@ -0,0 +1,126 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Repo_mirrorPushArgs(t *testing.T) {
+	defer func() {
+		mirrorRefs, mirrorExcludeRefs, mirrorNoDelete = nil, nil, false
+	}()
+
+	testCases := []struct {
+		name              string
+		refs              []string
+		excludeRefs       []string
+		noDelete          bool
+		repo              Repo
+		expectedArguments []string
+	}{
+		{
+			name:              "mirror",
+			repo:              Repo{mirrorURL: "git@github.com:acme/api.git"},
+			expectedArguments: []string{"push", "--porcelain", "--progress", "--mirror", "git@github.com:acme/api.git"},
+		},
+		{
+			name:        "run refs and excludes",
+			refs:        []string{"refs/heads/*", "refs/tags/*"},
+			excludeRefs: []string{"refs/heads/tmp/*"},
+			repo:        Repo{mirrorURL: "git@github.com:acme/api.git"},
+			expectedArguments: []string{
+				"push", "--porcelain", "--progress", "--force", "--prune", "git@github.com:acme/api.git",
+				"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*", "^refs/heads/tmp/*",
+			},
+		},
+		{
+			name:        "repo overrides refs and extends excludes",
+			refs:        []string{"refs/heads/*"},
+			excludeRefs: []string{"refs/pull/*"},
+			repo: Repo{
+				mirrorURL:         "git@github.com:acme/api.git",
+				MirrorRefs:        []string{"refs/heads/main"},
+				MirrorExcludeRefs: []string{"refs/merge-requests/*"},
+				MirrorNoDelete:    true,
+			},
+			expectedArguments: []string{
+				"push", "--porcelain", "--progress", "--force", "git@github.com:acme/api.git",
+				"+refs/heads/main:refs/heads/main", "^refs/pull/*", "^refs/merge-requests/*",
+			},
+		},
+		{
+			name:     "no delete",
+			noDelete: true,
+			repo:     Repo{mirrorURL: "git@github.com:acme/api.git"},
+			expectedArguments: []string{
+				"push", "--porcelain", "--progress", "--force", "git@github.com:acme/api.git", "+refs/*:refs/*",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mirrorRefs, mirrorExcludeRefs, mirrorNoDelete = tc.refs, tc.excludeRefs, tc.noDelete
+			assert.Equal(t, tc.expectedArguments, tc.repo.mirrorPushArgs())
+		})
+	}
+}
+
+func Test_parsePushRejections(t *testing.T) {
+	output := "To git@github.com:acme/api.git\n" +
+		"=\trefs/heads/main:refs/heads/main\t[up to date]\n" +
+		"!\trefs/pull/1/head:refs/pull/1/head\t[remote rejected] (deny updating a hidden ref)\n" +
+		"!\t:refs/heads/old\t[remote rejected] (deletion prohibited)\n" +
+		"Done\n"
+
+	assert.Equal(t, []string{
+		"refs/pull/1/head [remote rejected] (deny updating a hidden ref)",
+		"refs/heads/old [remote rejected] (deletion prohibited)",
+	}, parsePushRejections(output))
+	assert.Empty(t, parsePushRejections("To git@github.com:acme/api.git\nDone\n"))
+}
+
+func Test_Repo_PushMirror_RejectedRefs(t *testing.T) {
+	sourceDir := filepath.Join(t.TempDir(), "source")
+	mirrorDir := filepath.Join(t.TempDir(), "mirror.git")
+	initSourceRepo(t, sourceDir, nil)
+	gitRun(t, sourceDir, "update-ref", "refs/pull/1/head", "HEAD")
+	gitRun(t, "", "init", "-q", "--bare", mirrorDir)
+	gitRun(t, mirrorDir, "config", "receive.hideRefs", "refs/pull")
+
+	repo := Repo{URL: sourceDir, mirrorURL: mirrorDir, fullPath: sourceDir}
+	repo.SetShellRunner(shellRunner)
+
+	assert.True(t, repo.PushMirror())
+	assert.Equal(t, []string{"refs/pull/1/head [remote rejected] (deny updating a hidden ref)"}, repo.rejectedRefs)
+
+	// branch rejected by mirror hook fails the push
+	hook := filepath.Join(mirrorDir, "hooks", "update")
+	require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\n[ \"$1\" != refs/heads/main ]\n"), 0o755))
+	gitRun(t, sourceDir, "commit", "-q", "--allow-empty", "-m", "second")
+	assert.False(t, repo.PushMirror())
+	assert.Len(t, repo.rejectedRefs, 2)
+	assert.True(t, repo.status.Error)
+
+	repo.mirrorURL = filepath.Join(t.TempDir(), "missing.git")
+	assert.False(t, repo.PushMirror())
+}
+
+func Test_onlyHiddenRefsRejected(t *testing.T) {
+	assert.False(t, onlyHiddenRefsRejected(nil))
+	assert.True(t, onlyHiddenRefsRejected([]string{
+		"refs/pull/1/head [remote rejected] (deny updating a hidden ref)",
+		"refs/merge-requests/1/head [remote rejected] (deny updating a hidden ref)",
+	}))
+	assert.False(t, onlyHiddenRefsRejected([]string{
+		"refs/pull/1/head [remote rejected] (deny updating a hidden ref)",
+		"refs/heads/main [remote rejected] (protected branch hook declined)",
+	}))
+}
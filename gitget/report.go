@@ -0,0 +1,201 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// MirrorStatus - data structure to track repository mirror outcome for the run summary
+type MirrorStatus struct {
+	Created      bool          // mirror repository was created on the target provider
+	RefsPushed   int           // new refs pushed to mirror
+	RefsUpdated  int           // existing mirror refs updated
+	RefsDeleted  int           // mirror refs deleted
+	RefsRejected int           // refs rejected by mirror
+	Bytes        int64         // size of objects written to mirror as reported by `git push --progress`
+	Duration     time.Duration // time spent mirroring repository
+}
+
+// MirrorReport - mirror run summary entry of a repository written to the JSON report file
+type MirrorReport struct {
+	Repository      string   `json:"repository"`
+	Mirror          string   `json:"mirror"`
+	Skipped         bool     `json:"skipped"`
+	Created         bool     `json:"created"`
+	RefsPushed      int      `json:"refs_pushed"`
+	RefsUpdated     int      `json:"refs_updated"`
+	RefsDeleted     int      `json:"refs_deleted"`
+	RefsRejected    int      `json:"refs_rejected"`
+	RejectedRefs    []string `json:"rejected_refs,omitempty"`
+	Bytes           int64    `json:"bytes"`
+	DurationSeconds float64  `json:"duration_seconds"`
+	Error           string   `json:"error,omitempty"`
+	LFSError        string   `json:"lfs_error,omitempty"`
+}
+
+var (
+	// ( "Writing objects: 100% (5/5), 195.68 KiB | 15.05 MiB/s, done." )
+	pushBytesRe = regexp.MustCompile(`Writing objects: 100% \(\d+/\d+\), ([0-9.]+) (bytes|KiB|MiB|GiB)`)
+	// progress lines of `git push --progress` stderr, not useful in error messages
+	gitProgressRe = regexp.MustCompile(
+		`^(remote: )?(Enumerating objects|Counting objects|Delta compression using|Compressing objects|` +
+			`Writing objects|Resolving deltas|Total \d+ )`)
+	byteUnits = map[string]float64{"bytes": 1, "KiB": 1 << 10, "MiB": 1 << 20, "GiB": 1 << 30}
+)
+
+// countPushedRefs - returns number of new, updated and deleted refs from `git push --porcelain` output
+func countPushedRefs(pushOutput string) (pushed, updated, deleted int) {
+	for _, line := range strings.Split(pushOutput, "\n") {
+		if len(strings.Split(line, "\t")) < 3 {
+			continue
+		}
+		switch line[0] {
+		case '*':
+			pushed++
+		case ' ', '+':
+			updated++
+		case '-':
+			deleted++
+		}
+	}
+
+	return pushed, updated, deleted
+}
+
+// parsePushBytes - returns size of objects written to mirror from `git push --progress` stderr
+func parsePushBytes(progressOutput string) int64 {
+	matches := pushBytesRe.FindAllStringSubmatch(progressOutput, -1)
+	if len(matches) == 0 {
+		return 0
+	}
+	size, err := strconv.ParseFloat(matches[len(matches)-1][1], 64)
+	if err != nil {
+		return 0
+	}
+
+	return int64(size * byteUnits[matches[len(matches)-1][2]])
+}
+
+// stripGitProgress - removes progress lines from git command stderr
+func stripGitProgress(output string) string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		// progress updates of the same line are separated by carriage return
+		line = line[strings.LastIndex(line, "\r")+1:]
+		if strings.TrimSpace(line) == "" || gitProgressRe.MatchString(line) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// mirrorFailed - records mirror operation error in the repository status
+func (repo *Repo) mirrorFailed(err error, errorOutput string) {
+	repo.status.Error = true
+	repo.status.OperationErrorMessage = strings.TrimSpace(stripGitProgress(errorOutput))
+	if repo.status.OperationErrorMessage == "" {
+		repo.status.OperationErrorMessage = err.Error()
+	}
+}
+
+// mirrorReport - returns mirror run summary entries of all repositories
+func mirrorReport(repoList *RepoList) []MirrorReport {
+	report := make([]MirrorReport, 0, len(*repoList))
+	for _, repo := range *repoList {
+		report = append(report, MirrorReport{
+			Repository:      repo.URL,
+			Mirror:          repo.mirrorURL,
+			Skipped:         !repo.status.Processed,
+			Created:         repo.status.Mirror.Created,
+			RefsPushed:      repo.status.Mirror.RefsPushed,
+			RefsUpdated:     repo.status.Mirror.RefsUpdated,
+			RefsDeleted:     repo.status.Mirror.RefsDeleted,
+			RefsRejected:    repo.status.Mirror.RefsRejected,
+			RejectedRefs:    repo.rejectedRefs,
+			Bytes:           repo.status.Mirror.Bytes,
+			DurationSeconds: repo.status.Mirror.Duration.Seconds(),
+			Error:           repo.status.OperationErrorMessage,
+			LFSError:        repo.status.LFSErrorMessage,
+		})
+	}
+
+	return report
+}
+
+// printMirrorStatus - prints mirror run summary table, similar to `git get --status`, fields of skipped
+// repositories are printed as "-"
+func printMirrorStatus(repoList *RepoList) {
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 12, 2, 2, ' ', 0)
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "REPOSITORY\tMIRROR\tCREATED\tPUSHED\tUPDATED\tDELETED\tREJECTED\tBYTES\tDURATION\tLFS_ERROR\tERROR")
+	for _, repo := range *repoList {
+		if repo.status.Processed {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%d\t%d\t%d\t%d\t%d\t%s\t%t\t%t\n",
+				repo.URL,
+				repo.mirrorURL,
+				repo.status.Mirror.Created,
+				repo.status.Mirror.RefsPushed,
+				repo.status.Mirror.RefsUpdated,
+				repo.status.Mirror.RefsDeleted,
+				repo.status.Mirror.RefsRejected,
+				repo.status.Mirror.Bytes,
+				repo.status.Mirror.Duration.Round(time.Millisecond),
+				repo.status.LFSError,
+				repo.status.Error,
+			)
+		} else {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t-\t-\t-\t-\t-\n", repo.URL)
+		}
+	}
+	fmt.Fprintln(w)
+	w.Flush()
+}
+
+// writeMirrorReport - writes mirror run summary of all repositories to JSON file
+func writeMirrorReport(reportFile string, repoList *RepoList) {
+	reportData, err := json.MarshalIndent(mirrorReport(repoList), "", "  ")
+	if err != nil {
+		log.Fatalf("%s: %s", reportFile, err)
+		os.Exit(1)
+	}
+
+	log.Infof("Writing mirror report file '%s'", reportFile)
+	if err := os.WriteFile(reportFile, append(reportData, '\n'), 0o600); err != nil {
+		log.Fatalf("%s: %s", reportFile, err)
+		os.Exit(1)
+	}
+}
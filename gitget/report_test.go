@@ -0,0 +1,141 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_countPushedRefs(t *testing.T) {
+	output := "To git@github.com:acme/api.git\n" +
+		"+\trefs/heads/main:refs/heads/main\t9daaa92...f9dcd2a (forced update)\n" +
+		" \trefs/heads/dev:refs/heads/dev\t1a2b3c4..5d6e7f8\n" +
+		"-\t:refs/heads/old\t[deleted]\n" +
+		"*\trefs/heads/new:refs/heads/new\t[new branch]\n" +
+		"*\trefs/tags/v1:refs/tags/v1\t[new tag]\n" +
+		"=\trefs/heads/stable:refs/heads/stable\t[up to date]\n" +
+		"!\trefs/pull/1/head:refs/pull/1/head\t[remote rejected] (deny updating a hidden ref)\n" +
+		"Done\n"
+
+	pushed, updated, deleted := countPushedRefs(output)
+	assert.Equal(t, 2, pushed)
+	assert.Equal(t, 2, updated)
+	assert.Equal(t, 1, deleted)
+}
+
+func Test_parsePushBytes(t *testing.T) {
+	assert.Equal(t, int64(200386), parsePushBytes(
+		"Writing objects:  50% (1/2)\rWriting objects: 100% (2/2)\r"+
+			"Writing objects: 100% (2/2), 195.69 KiB | 15.05 MiB/s, done.\n"))
+	assert.Equal(t, int64(231), parsePushBytes("Writing objects: 100% (3/3), 231 bytes | 231.00 KiB/s, done.\n"))
+	assert.Equal(t, int64(0), parsePushBytes("Everything up-to-date\n"))
+}
+
+func Test_stripGitProgress(t *testing.T) {
+	output := "Enumerating objects: 5, done.\n" +
+		"Counting objects:  50% (1/2)\rCounting objects: 100% (2/2), done.\n" +
+		"Writing objects: 100% (2/2), 231 bytes | 231.00 KiB/s, done.\n" +
+		"Total 2 (delta 0), reused 0 (delta 0), pack-reused 0\n" +
+		"remote: Resolving deltas: 100% (1/1), done.\n" +
+		"remote: error: GH013: Repository rule violations found\n" +
+		"error: failed to push some refs to 'git@github.com:acme/api.git'\n"
+
+	assert.Equal(t,
+		"remote: error: GH013: Repository rule violations found\n"+
+			"error: failed to push some refs to 'git@github.com:acme/api.git'",
+		stripGitProgress(output))
+}
+
+func Test_Repo_PushMirror_Status(t *testing.T) {
+	sourceDir := filepath.Join(t.TempDir(), "source")
+	mirrorDir := filepath.Join(t.TempDir(), "mirror.git")
+	initSourceRepo(t, sourceDir, nil)
+	gitRun(t, sourceDir, "branch", "old")
+	gitRun(t, "", "init", "-q", "--bare", mirrorDir)
+
+	repo := Repo{URL: sourceDir, mirrorURL: mirrorDir, fullPath: sourceDir}
+	repo.SetShellRunner(shellRunner)
+
+	assert.True(t, repo.PushMirror())
+	assert.Equal(t, 2, repo.status.Mirror.RefsPushed)
+	assert.Greater(t, repo.status.Mirror.Bytes, int64(0))
+
+	gitRun(t, sourceDir, "commit", "-q", "--allow-empty", "-m", "second")
+	gitRun(t, sourceDir, "branch", "-D", "old")
+	assert.True(t, repo.PushMirror())
+	assert.Equal(t, MirrorStatus{RefsUpdated: 1, RefsDeleted: 1, Bytes: repo.status.Mirror.Bytes}, repo.status.Mirror)
+	assert.False(t, repo.status.Error)
+
+	repo.mirrorURL = filepath.Join(t.TempDir(), "missing.git")
+	assert.False(t, repo.PushMirror())
+	assert.True(t, repo.status.Error)
+	assert.Contains(t, repo.status.OperationErrorMessage, "missing.git")
+	assert.NotContains(t, repo.status.OperationErrorMessage, "Enumerating objects")
+}
+
+func Test_writeMirrorReport(t *testing.T) {
+	repoList := RepoList{
+		{
+			URL:          "git@github.com:acme/api.git",
+			mirrorURL:    "git@gitlab.com:mirrors/api.git",
+			rejectedRefs: []string{"refs/pull/1/head [remote rejected] (deny updating a hidden ref)"},
+			status: RepoStatus{
+				Processed: true,
+				Mirror: MirrorStatus{
+					Created:      true,
+					RefsPushed:   3,
+					RefsRejected: 1,
+					Bytes:        1024,
+					Duration:     1500 * time.Millisecond,
+				},
+			},
+		},
+		{
+			URL:       "git@github.com:acme/web.git",
+			mirrorURL: "git@gitlab.com:mirrors/web.git",
+			status: RepoStatus{
+				Processed:             true,
+				Error:                 true,
+				OperationErrorMessage: "fatal: repository not found",
+			},
+		},
+		{URL: "git@github.com:acme/ignored.git"},
+	}
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+
+	writeMirrorReport(reportFile, &repoList)
+
+	reportData, err := os.ReadFile(reportFile)
+	require.NoError(t, err)
+	var report []MirrorReport
+	require.NoError(t, json.Unmarshal(reportData, &report))
+	assert.Equal(t, []MirrorReport{
+		{
+			Repository:      "git@github.com:acme/api.git",
+			Mirror:          "git@gitlab.com:mirrors/api.git",
+			Created:         true,
+			RefsPushed:      3,
+			RefsRejected:    1,
+			RejectedRefs:    []string{"refs/pull/1/head [remote rejected] (deny updating a hidden ref)"},
+			Bytes:           1024,
+			DurationSeconds: 1.5,
+		},
+		{
+			Repository: "git@github.com:acme/web.git",
+			Mirror:     "git@gitlab.com:mirrors/web.git",
+			Error:      "fatal: repository not found",
+		},
+		{
+			Repository: "git@github.com:acme/ignored.git",
+			Skipped:    true,
+		},
+	}, report)
+}
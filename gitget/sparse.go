@@ -0,0 +1,103 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// partial clone filters supported in Gitfile ( blob:none, tree:0, blob:limit=1m )
+var cloneFilterRe = regexp.MustCompile(`^(blob:none|tree:0|blob:limit=[0-9]+[kmg]?)$`)
+
+// validateCloneFilters - ensures partial clone filters of all repositories are supported
+func validateCloneFilters(repoList *RepoList) {
+	for _, repo := range *repoList {
+		if repo.Filter != "" && !cloneFilterRe.MatchString(repo.Filter) {
+			log.Fatalf(
+				"Error: unknown '%s' filter of '%s', supported filters are blob:none, tree:0 and blob:limit=<size>",
+				repo.Filter, repo.URL)
+			os.Exit(1)
+		}
+	}
+}
+
+// cloneArgs - returns `git clone` arguments, partial clone filter limits fetched objects and sparse
+// repositories check out only top level files until sparse checkout paths are set
+func (repo *Repo) cloneArgs(shallow bool) []string {
+	args := []string{"clone"}
+	if shallow {
+		args = append(args, "--depth", "1")
+	}
+	if repo.Filter != "" {
+		args = append(args, "--filter="+repo.Filter)
+	}
+	if len(repo.Sparse) > 0 {
+		args = append(args, "--sparse")
+	}
+	if repo.referencePath != "" {
+		args = append(args, "--reference-if-able", repo.referencePath, "--dissociate")
+	}
+
+	return append(args, "--branch", repo.Ref, repo.URL, repo.fullPath)
+}
+
+// sparseCheckoutEnabled - checks if sparse checkout is enabled in the repository clone
+func (repo *Repo) sparseCheckoutEnabled() bool {
+	var outb bytes.Buffer
+	_, err := (*repo.executor).ExecGitCommand(
+		[]string{"config", "--bool", "core.sparseCheckout"},
+		&outb,
+		nil,
+		repo.fullPath,
+	)
+
+	return err == nil && strings.TrimSpace(outb.String()) == "true"
+}
+
+// SparseCheckout runs `git sparse-checkout set` with repository sparse paths, when sparse paths are
+// removed from Gitfile, sparse checkout enabled by the previous run is disabled
+func (repo *Repo) SparseCheckout() bool {
+	args := append([]string{"sparse-checkout", "set"}, repo.Sparse...)
+	if len(repo.Sparse) == 0 {
+		if !repo.sparseCheckoutEnabled() {
+			return true
+		}
+		args = []string{"sparse-checkout", "disable"}
+	}
+
+	log.Infof("%s: Update sparse checkout '%s'", repo.sha, strings.Join(args[1:], " "))
+	var serr bytes.Buffer
+	_, err := (*repo.executor).ExecGitCommand(args, nil, &serr, repo.fullPath)
+	if err != nil {
+		repo.status.Error = true
+		log.Errorf("%s: %v %v", repo.sha, err, serr.String())
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,71 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_cloneFilterRe(t *testing.T) {
+	for _, filter := range []string{"blob:none", "tree:0", "blob:limit=1m", "blob:limit=1024"} {
+		assert.True(t, cloneFilterRe.MatchString(filter), filter)
+	}
+	for _, filter := range []string{"", "blob", "tree:1", "blob:limit=", "sparse:oid=HEAD"} {
+		assert.False(t, cloneFilterRe.MatchString(filter), filter)
+	}
+}
+
+func Test_Repo_cloneArgs(t *testing.T) {
+	repo := Repo{URL: "git@github.com:acme/mono.git", Ref: "main", fullPath: "mono"}
+	assert.Equal(t, []string{"clone", "--branch", "main", repo.URL, "mono"}, repo.cloneArgs(false))
+
+	repo.Filter = "blob:none"
+	repo.Sparse = []string{"services/api"}
+	assert.Equal(t,
+		[]string{"clone", "--depth", "1", "--filter=blob:none", "--sparse", "--branch", "main", repo.URL, "mono"},
+		repo.cloneArgs(true))
+
+	repo.referencePath = "/cache/github.com/acme/mono.git"
+	assert.Equal(t,
+		[]string{"clone", "--depth", "1", "--filter=blob:none", "--sparse", "--reference-if-able", "/cache/github.com/acme/mono.git", "--dissociate", "--branch", "main", repo.URL, "mono"},
+		repo.cloneArgs(true))
+}
+
+func Test_Repo_SparseCheckout(t *testing.T) {
+	sourceDir := filepath.Join(t.TempDir(), "source")
+	initSourceRepo(t, sourceDir, map[string]string{"a/file": "a", "b/file": "b", "README": "top"})
+	gitRun(t, sourceDir, "config", "uploadpack.allowFilter", "true")
+
+	repo := Repo{
+		URL:      "file://" + sourceDir,
+		Ref:      "main",
+		Sparse:   []string{"a"},
+		Filter:   "blob:none",
+		fullPath: filepath.Join(t.TempDir(), "clone"),
+	}
+	repo.SetShellRunner(shellRunner)
+
+	require.True(t, repo.Clone())
+	assert.FileExists(t, filepath.Join(repo.fullPath, "README"))
+	assert.FileExists(t, filepath.Join(repo.fullPath, "a", "file"))
+	assert.NoDirExists(t, filepath.Join(repo.fullPath, "b"))
+	assert.True(t, repo.sparseCheckoutEnabled())
+
+	// sparse paths changed in Gitfile
+	repo.Sparse = []string{"b"}
+	assert.True(t, repo.SparseCheckout())
+	assert.NoDirExists(t, filepath.Join(repo.fullPath, "a"))
+	assert.FileExists(t, filepath.Join(repo.fullPath, "b", "file"))
+
+	// sparse paths removed from Gitfile
+	repo.Sparse = nil
+	assert.True(t, repo.SparseCheckout())
+	assert.False(t, repo.sparseCheckoutEnabled())
+	assert.FileExists(t, filepath.Join(repo.fullPath, "a", "file"))
+	assert.False(t, repo.status.Error)
+}
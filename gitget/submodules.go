@@ -0,0 +1,133 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Submodules modes
+const (
+	SubmodulesNone      = "none"      // submodules are not initialised
+	SubmodulesInit      = "init"      // top level submodules are initialised and updated
+	SubmodulesRecursive = "recursive" // submodules and their nested submodules are initialised and updated
+)
+
+// validSubmodulesMode - checks if submodules mode is known, empty mode defaults to the per run one
+func validSubmodulesMode(mode string) bool {
+	switch mode {
+	case "", SubmodulesNone, SubmodulesInit, SubmodulesRecursive:
+		return true
+	default:
+		return false
+	}
+}
+
+// validateSubmodules - ensures per run and all repositories submodules modes are known
+func validateSubmodules(repoList *RepoList) {
+	if !validSubmodulesMode(submodulesMode) {
+		log.Fatalf("Error: unknown '%s' submodules mode", submodulesMode)
+		os.Exit(1)
+	}
+	for _, repo := range *repoList {
+		if !validSubmodulesMode(repo.Submodules) {
+			log.Fatalf("Error: unknown '%s' submodules mode of '%s'", repo.Submodules, repo.URL)
+			os.Exit(1)
+		}
+	}
+}
+
+// submodules - returns submodules mode of the repository, repository mode overrides per run one
+func (repo *Repo) submodules() string {
+	if repo.Submodules != "" {
+		return repo.Submodules
+	}
+	if submodulesMode == "" {
+		return SubmodulesNone
+	}
+
+	return submodulesMode
+}
+
+// UpdateSubmodules runs `git submodule update --init` after clone or refresh, same as
+// `git clone --recurse-submodules` (`--shallow-submodules` in shallow mode) does, but failed
+// submodules are reported separately from the repository clone, repositories without
+// `.gitmodules` are skipped
+func (repo *Repo) UpdateSubmodules(shallow bool) bool {
+	mode := repo.submodules()
+	if mode == SubmodulesNone {
+		return true
+	}
+	if exists, _ := PathExists(filepath.Join(repo.fullPath, ".gitmodules")); !exists {
+		return true
+	}
+
+	log.Infof("%s: Update submodules (%s)", repo.sha, mode)
+	args := []string{"submodule", "update", "--init"}
+	if mode == SubmodulesRecursive {
+		args = append(args, "--recursive")
+	}
+	if shallow {
+		args = append(args, "--depth", "1")
+	}
+
+	var serr bytes.Buffer
+	repo.submodulesUpdated = true
+	_, err := (*repo.executor).ExecGitCommand(args, nil, &serr, repo.fullPath)
+	if err != nil {
+		repo.status.SubmodulesError = true
+		repo.status.SubmodulesErrorMessage = strings.TrimSpace(serr.String())
+		if repo.status.SubmodulesErrorMessage == "" {
+			repo.status.SubmodulesErrorMessage = err.Error()
+		}
+		log.Errorf("%s: git submodule update: %v %v", repo.sha, err, serr.String())
+		return false
+	}
+
+	return true
+}
+
+// RemoveSubmodulesGitFiles removes `.git` files of submodules, which point to removed `.git`
+// directory of the shallow cloned repository, failure is reported as submodules error
+func (repo *Repo) RemoveSubmodulesGitFiles() {
+	err := filepath.WalkDir(repo.fullPath, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Name() == ".git" && !entry.IsDir() {
+			return os.Remove(filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		repo.status.SubmodulesError = true
+		repo.status.SubmodulesErrorMessage = err.Error()
+		log.Errorf("%s: Unable to remove submodules '.git' files: %v", repo.sha, err)
+	}
+}
@@ -0,0 +1,130 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// allowFileSubmodules - allows local path submodules, which are disabled by default since git 2.38.1
+func allowFileSubmodules(t *testing.T) {
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+}
+
+// submodulesSource - creates repository with submodule, which has nested submodule
+func submodulesSource(t *testing.T) string {
+	baseDir := t.TempDir()
+	for _, name := range []string{"nested", "sub", "top"} {
+		initSourceRepo(t, filepath.Join(baseDir, name), nil)
+	}
+	gitRun(t, filepath.Join(baseDir, "sub"), "submodule", "add", "-q", filepath.Join(baseDir, "nested"), "nested")
+	gitRun(t, filepath.Join(baseDir, "sub"), "commit", "-q", "-m", "nested")
+	gitRun(t, filepath.Join(baseDir, "top"), "submodule", "add", "-q", filepath.Join(baseDir, "sub"), "sub")
+	gitRun(t, filepath.Join(baseDir, "top"), "commit", "-q", "-m", "sub")
+
+	return filepath.Join(baseDir, "top")
+}
+
+func Test_validSubmodulesMode(t *testing.T) {
+	assert.True(t, validSubmodulesMode(""))
+	assert.True(t, validSubmodulesMode(SubmodulesNone))
+	assert.True(t, validSubmodulesMode(SubmodulesInit))
+	assert.True(t, validSubmodulesMode(SubmodulesRecursive))
+	assert.False(t, validSubmodulesMode("all"))
+}
+
+func Test_Repo_submodules(t *testing.T) {
+	defer func() { submodulesMode = SubmodulesNone }()
+
+	submodulesMode = SubmodulesInit
+	assert.Equal(t, SubmodulesInit, (&Repo{}).submodules())
+	assert.Equal(t, SubmodulesNone, (&Repo{Submodules: SubmodulesNone}).submodules())
+	submodulesMode = ""
+	assert.Equal(t, SubmodulesNone, (&Repo{}).submodules())
+}
+
+func Test_Repo_UpdateSubmodules(t *testing.T) {
+	allowFileSubmodules(t)
+	sourceDir := submodulesSource(t)
+
+	testCases := []struct {
+		name           string
+		mode           string
+		expectedFiles  []string
+		missingFiles   []string
+		expectedResult bool
+	}{
+		{name: "none", mode: SubmodulesNone, missingFiles: []string{"sub/.git"}, expectedResult: true},
+		{name: "init", mode: SubmodulesInit, expectedFiles: []string{"sub/.git"}, missingFiles: []string{"sub/nested/.git"}, expectedResult: true},
+		{name: "recursive", mode: SubmodulesRecursive, expectedFiles: []string{"sub/.git", "sub/nested/.git"}, expectedResult: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := Repo{URL: sourceDir, Ref: "main", Submodules: tc.mode, fullPath: filepath.Join(t.TempDir(), "top")}
+			repo.SetShellRunner(shellRunner)
+
+			require.True(t, repo.Clone())
+			assert.Equal(t, tc.expectedResult, repo.UpdateSubmodules(false))
+			for _, file := range tc.expectedFiles {
+				assert.FileExists(t, filepath.Join(repo.fullPath, file))
+			}
+			for _, file := range tc.missingFiles {
+				assert.NoFileExists(t, filepath.Join(repo.fullPath, file))
+			}
+		})
+	}
+}
+
+func Test_Repo_UpdateSubmodules_Error(t *testing.T) {
+	allowFileSubmodules(t)
+	sourceDir := submodulesSource(t)
+	repo := Repo{URL: sourceDir, Ref: "main", Submodules: SubmodulesInit, fullPath: filepath.Join(t.TempDir(), "top")}
+	repo.SetShellRunner(shellRunner)
+	require.True(t, repo.Clone())
+	require.NoError(t, os.Rename(filepath.Join(filepath.Dir(sourceDir), "sub"), filepath.Join(filepath.Dir(sourceDir), "moved")))
+
+	assert.False(t, repo.UpdateSubmodules(false))
+	assert.False(t, repo.status.Error)
+	assert.True(t, repo.status.SubmodulesError)
+	assert.Contains(t, repo.status.SubmodulesErrorMessage, "sub")
+}
+
+func Test_Repo_RemoveSubmodulesGitFiles(t *testing.T) {
+	allowFileSubmodules(t)
+	sourceDir := submodulesSource(t)
+	repo := Repo{URL: "file://" + sourceDir, Ref: "main", Submodules: SubmodulesRecursive, fullPath: filepath.Join(t.TempDir(), "top")}
+	repo.SetShellRunner(shellRunner)
+
+	require.True(t, repo.ShallowClone())
+	assert.True(t, repo.UpdateSubmodules(true))
+	assert.True(t, repo.submodulesUpdated)
+	repo.RemoveTargetDir(true)
+	repo.RemoveSubmodulesGitFiles()
+
+	assert.NoFileExists(t, filepath.Join(repo.fullPath, "sub", ".git"))
+	assert.NoFileExists(t, filepath.Join(repo.fullPath, "sub", "nested", ".git"))
+	assert.FileExists(t, filepath.Join(repo.fullPath, "sub", ".gitmodules"))
+	assert.False(t, repo.status.SubmodulesError)
+
+	// submodules are not updated with submodules disabled
+	disabled := Repo{URL: repo.URL, Ref: "main", Submodules: SubmodulesNone, fullPath: filepath.Join(t.TempDir(), "top")}
+	disabled.SetShellRunner(shellRunner)
+	require.True(t, disabled.ShallowClone())
+	assert.True(t, disabled.UpdateSubmodules(true))
+	assert.False(t, disabled.submodulesUpdated)
+
+	// walk failure is reported as submodules error
+	missing := Repo{fullPath: filepath.Join(t.TempDir(), "missing")}
+	missing.RemoveSubmodulesGitFiles()
+	assert.True(t, missing.status.SubmodulesError)
+	assert.NotEmpty(t, missing.status.SubmodulesErrorMessage)
+}
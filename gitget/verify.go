@@ -0,0 +1,332 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitget
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// MirrorVerifyStatus - data structure to track refs differences between source and mirror repository
+type MirrorVerifyStatus struct {
+	Missing   []string // source refs missing in mirror
+	Extra     []string // mirror refs missing in source
+	Divergent []string // refs pointing to different objects in source and mirror
+}
+
+// MirrorVerifyReport - mirror verification entry of a repository written to the JSON report file
+type MirrorVerifyReport struct {
+	Repository string   `json:"repository"`
+	Mirror     string   `json:"mirror"`
+	Skipped    bool     `json:"skipped"`
+	Consistent bool     `json:"consistent"`
+	Missing    []string `json:"missing,omitempty"`
+	Extra      []string `json:"extra,omitempty"`
+	Divergent  []string `json:"divergent,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// consistent - mirror has the same refs as source repository
+func (repo *Repo) consistent() bool {
+	return repo.status.Processed && !repo.status.Error &&
+		len(repo.status.Verify.Missing) == 0 &&
+		len(repo.status.Verify.Extra) == 0 &&
+		len(repo.status.Verify.Divergent) == 0
+}
+
+// PrepareForVerify - sets repository and mirror URLs and git runner, nothing is cloned for verification
+func (repo *Repo) PrepareForVerify(mirrorRootURL string) {
+	repo.SetShellRunner(shellRunner)
+	repo.SetDefaultRef()
+	repo.SetRepoLocalName()
+	repo.SetMirrorURL(mirrorRootURL)
+	repo.SetSha()
+	repo.SetHTTPSTokenAuth()
+
+	log.Infof("%s: url: %s -> %s", repo.sha, repo.URL, repo.mirrorURL)
+}
+
+// refMatches - checks if ref matches git refspec pattern, which can contain single `*` matching any
+// part of the ref including `/`
+func refMatches(pattern, ref string) bool {
+	prefix, suffix, found := strings.Cut(pattern, "*")
+	if !found {
+		return pattern == ref
+	}
+
+	return len(ref) >= len(prefix)+len(suffix) && strings.HasPrefix(ref, prefix) && strings.HasSuffix(ref, suffix)
+}
+
+// mirroredRef - checks if ref is pushed to mirror according to repository refs filters
+func (repo *Repo) mirroredRef(ref string) bool {
+	includeRefs, excludeRefs, _ := repo.mirrorRefFilters()
+	included := len(includeRefs) == 0
+	for _, includeRef := range includeRefs {
+		included = included || refMatches(includeRef, ref)
+	}
+	for _, excludeRef := range excludeRefs {
+		included = included && !refMatches(excludeRef, ref)
+	}
+
+	return included
+}
+
+// LsRemote - returns refs of the remote repository with object names they point to, symbolic
+// `HEAD` and peeled tags are not returned
+func (repo *Repo) LsRemote(remoteURL string) (map[string]string, bool) {
+	var outb, serr bytes.Buffer
+	_, err := (*repo.executor).ExecGitCommand(
+		[]string{"ls-remote", remoteURL},
+		&outb,
+		&serr,
+		"",
+	)
+	if err != nil {
+		repo.mirrorFailed(err, serr.String())
+		log.Errorf("%s: %v %v", repo.sha, err, serr.String())
+		return nil, false
+	}
+
+	refs := map[string]string{}
+	for _, line := range strings.Split(outb.String(), "\n") {
+		objectName, ref, found := strings.Cut(line, "\t")
+		if !found || !strings.HasPrefix(ref, "refs/") || strings.HasSuffix(ref, "^{}") || !repo.mirroredRef(ref) {
+			continue
+		}
+		refs[ref] = objectName
+	}
+
+	return refs, true
+}
+
+// compareRefs - returns sorted lists of source refs missing in mirror, mirror refs missing in
+// source and refs pointing to different objects
+func compareRefs(sourceRefs, mirrorRefs map[string]string) (missing, extra, divergent []string) {
+	for ref, objectName := range sourceRefs {
+		mirrorObjectName, found := mirrorRefs[ref]
+		if !found {
+			missing = append(missing, ref)
+		} else if mirrorObjectName != objectName {
+			divergent = append(divergent, ref)
+		}
+	}
+	for ref := range mirrorRefs {
+		if _, found := sourceRefs[ref]; !found {
+			extra = append(extra, ref)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+	sort.Strings(divergent)
+
+	return missing, extra, divergent
+}
+
+// VerifyMirror - compares refs of the source and mirror repositories without cloning them, mirror
+// refs missing in source are expected in no-delete mode and are not reported
+func (repo *Repo) VerifyMirror() bool {
+	log.Infof("%s: Verify mirror '%s' of repository '%s'", repo.sha, repo.mirrorURL, repo.URL)
+	sourceRefs, ok := repo.LsRemote(repo.URL)
+	if !ok {
+		return false
+	}
+	mirrorRefs, ok := repo.LsRemote(repo.mirrorURL)
+	if !ok {
+		return false
+	}
+
+	verifyStatus := &repo.status.Verify
+	verifyStatus.Missing, verifyStatus.Extra, verifyStatus.Divergent = compareRefs(sourceRefs, mirrorRefs)
+	if _, _, noDelete := repo.mirrorRefFilters(); noDelete {
+		verifyStatus.Extra = nil
+	}
+
+	for _, ref := range verifyStatus.Missing {
+		log.Warnf("%s: ref '%s' is missing in mirror '%s'", repo.sha, ref, repo.mirrorURL)
+	}
+	for _, ref := range verifyStatus.Extra {
+		log.Warnf("%s: ref '%s' of mirror '%s' is missing in source", repo.sha, ref, repo.mirrorURL)
+	}
+	for _, ref := range verifyStatus.Divergent {
+		log.Warnf("%s: ref '%s' differs in mirror '%s'", repo.sha, ref, repo.mirrorURL)
+	}
+
+	return len(verifyStatus.Missing) == 0 && len(verifyStatus.Extra) == 0 && len(verifyStatus.Divergent) == 0
+}
+
+func verifyMirrorsFromConfigInParallel(
+	repoList *RepoList,
+	ignoreRepoList []Repo,
+	concurrencyLevel int,
+	mirrorRootURL string,
+) {
+	throttle := make(chan int, concurrencyLevel)
+
+	var wait sync.WaitGroup
+
+	for i := 0; i < len(*repoList); i++ {
+		throttle <- 1
+		wait.Add(1)
+
+		go func(repository *Repo, iwait *sync.WaitGroup, ithrottle chan int) {
+			defer iwait.Done()
+
+			if !ignoreThisRepo(repository.URL, ignoreRepoList) {
+				log.Debugf("%s: process repo: '%s'", repository.sha, repository.URL)
+				repository.PrepareForVerify(mirrorRootURL)
+				repository.VerifyMirror()
+				repository.status.Processed = true
+			}
+
+			<-ithrottle
+		}(&(*repoList)[i], &wait, throttle)
+	}
+
+	wait.Wait()
+}
+
+// printMirrorVerifyStatus - prints number of differing refs of every repository
+func printMirrorVerifyStatus(repoList *RepoList) {
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 12, 2, 2, ' ', 0)
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "REPOSITORY\tMIRROR\tMISSING\tEXTRA\tDIVERGENT\tERROR\tSKIPPED\tCONSISTENT")
+	for _, repo := range *repoList {
+		if repo.status.Processed {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%t\t%t\t%t\n",
+				repo.URL,
+				repo.mirrorURL,
+				len(repo.status.Verify.Missing),
+				len(repo.status.Verify.Extra),
+				len(repo.status.Verify.Divergent),
+				repo.status.Error,
+				!repo.status.Processed,
+				repo.consistent(),
+			)
+		} else {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t%t\t-\n", repo.URL, !repo.status.Processed)
+		}
+	}
+	fmt.Fprintln(w)
+	w.Flush()
+}
+
+// writeMirrorVerifyReport - writes refs differences of all repositories to JSON file
+func writeMirrorVerifyReport(reportFile string, repoList *RepoList) {
+	report := make([]MirrorVerifyReport, 0, len(*repoList))
+	for _, repo := range *repoList {
+		report = append(report, MirrorVerifyReport{
+			Repository: repo.URL,
+			Mirror:     repo.mirrorURL,
+			Skipped:    !repo.status.Processed,
+			Consistent: repo.consistent(),
+			Missing:    repo.status.Verify.Missing,
+			Extra:      repo.status.Verify.Extra,
+			Divergent:  repo.status.Verify.Divergent,
+			Error:      repo.status.OperationErrorMessage,
+		})
+	}
+
+	reportData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatalf("%s: %s", reportFile, err)
+		os.Exit(1)
+	}
+
+	log.Infof("Writing mirror verification report file '%s'", reportFile)
+	if err := os.WriteFile(reportFile, append(reportData, '\n'), 0o600); err != nil {
+		log.Fatalf("%s: %s", reportFile, err)
+		os.Exit(1)
+	}
+}
+
+// VerifyMirrors - Entry point for mirror verification logic, compares refs of source repositories
+// with their mirrors and returns false if any mirror is inconsistent or could not be verified
+func VerifyMirrors(
+	cfgFiles []string,
+	ignoreFiles []string,
+	concurrencyLevel int,
+	mirrorRootURL string,
+	mirrorProviderName string,
+	httpsAuth bool,
+	mirrorNamingStrategy string,
+	mirrorNamingTemplate string,
+	mirrorGitlabNamespaceID int64,
+	pushRefs []string,
+	pushExcludeRefs []string,
+	pushNoDelete bool,
+	reportFile string,
+) bool {
+	initColors()
+	gitProvider = mirrorProviderName
+	httpsTokenAuth = httpsAuth
+	mirrorNaming = mirrorNamingStrategy
+	validateMirrorNaming(mirrorNamingTemplate)
+	gitlabMirrorNamespaceID = mirrorGitlabNamespaceID
+	validateGitlabNamespaceID()
+	mirrorRefs = pushRefs
+	mirrorExcludeRefs = pushExcludeRefs
+	mirrorNoDelete = pushNoDelete
+	validateMirrorURL(mirrorRootURL)
+	setHostProvider(mirrorRootURL, gitProvider, nil)
+
+	repoList := GetConfigRepoList(cfgFiles)
+	log.Debugf("Total number of repositories to verify: '%d'", len(*repoList))
+
+	ignoreRepoList := GetIgnoreRepoList(ignoreFiles)
+	log.Debugf("Total number of repositories to ignore: '%d'", len(ignoreRepoList))
+
+	if gitlabMirrorNamespaceID != 0 {
+		mirrorRootURL = gitlabNamespaceRootURL(NewProviders(mirrorProviderName, mirrorRootURL).Gitlab, mirrorRootURL)
+	}
+
+	checkMirrorNames(repoList, ignoreRepoList, mirrorRootURL)
+
+	verifyMirrorsFromConfigInParallel(repoList, ignoreRepoList, concurrencyLevel, mirrorRootURL)
+	printMirrorVerifyStatus(repoList)
+	if reportFile != "" {
+		writeMirrorVerifyReport(reportFile, repoList)
+	}
+
+	inconsistent := 0
+	for _, repo := range *repoList {
+		if repo.status.Processed && !repo.consistent() {
+			inconsistent++
+		}
+	}
+	if inconsistent > 0 {
+		log.Errorf("%d mirror(s) are not consistent with source repositories", inconsistent)
+		return false
+	}
+
+	return true
+}
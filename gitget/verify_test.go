@@ -0,0 +1,84 @@
+//go:build !integration
+// +build !integration
+
+package gitget
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_refMatches(t *testing.T) {
+	assert.True(t, refMatches("refs/heads/main", "refs/heads/main"))
+	assert.False(t, refMatches("refs/heads/main", "refs/heads/main2"))
+	assert.True(t, refMatches("refs/heads/*", "refs/heads/feature/a"))
+	assert.True(t, refMatches("refs/heads/*/a", "refs/heads/feature/a"))
+	assert.False(t, refMatches("refs/heads/*", "refs/tags/v1"))
+	assert.False(t, refMatches("refs/heads/*/a", "refs/heads/a"))
+}
+
+func Test_Repo_mirroredRef(t *testing.T) {
+	defer func() {
+		mirrorRefs, mirrorExcludeRefs = nil, nil
+	}()
+
+	repo := Repo{}
+	assert.True(t, repo.mirroredRef("refs/pull/1/head"))
+
+	mirrorRefs = []string{"refs/heads/*", "refs/tags/*"}
+	mirrorExcludeRefs = []string{"refs/heads/tmp/*"}
+	assert.True(t, repo.mirroredRef("refs/heads/main"))
+	assert.True(t, repo.mirroredRef("refs/tags/v1"))
+	assert.False(t, repo.mirroredRef("refs/heads/tmp/a"))
+	assert.False(t, repo.mirroredRef("refs/pull/1/head"))
+
+	repo.MirrorExcludeRefs = []string{"refs/tags/*"}
+	assert.False(t, repo.mirroredRef("refs/tags/v1"))
+}
+
+func Test_compareRefs(t *testing.T) {
+	missing, extra, divergent := compareRefs(
+		map[string]string{"refs/heads/main": "a", "refs/heads/dev": "b", "refs/tags/v2": "c", "refs/tags/v1": "d"},
+		map[string]string{"refs/heads/main": "a", "refs/heads/dev": "e", "refs/heads/old": "f"},
+	)
+	assert.Equal(t, []string{"refs/tags/v1", "refs/tags/v2"}, missing)
+	assert.Equal(t, []string{"refs/heads/old"}, extra)
+	assert.Equal(t, []string{"refs/heads/dev"}, divergent)
+}
+
+func Test_Repo_VerifyMirror(t *testing.T) {
+	defer func() { mirrorNoDelete = false }()
+
+	sourceDir := filepath.Join(t.TempDir(), "source")
+	mirrorDir := filepath.Join(t.TempDir(), "mirror.git")
+	initSourceRepo(t, sourceDir, nil)
+	gitRun(t, sourceDir, "tag", "-a", "-m", "v1", "v1")
+	gitRun(t, sourceDir, "branch", "old")
+	gitRun(t, "", "clone", "-q", "--mirror", sourceDir, mirrorDir)
+
+	repo := Repo{URL: sourceDir, mirrorURL: mirrorDir}
+	repo.SetShellRunner(shellRunner)
+	assert.True(t, repo.VerifyMirror())
+	assert.Equal(t, MirrorVerifyStatus{}, repo.status.Verify)
+
+	gitRun(t, sourceDir, "commit", "-q", "--allow-empty", "-m", "second")
+	gitRun(t, sourceDir, "branch", "-D", "old")
+	gitRun(t, sourceDir, "branch", "feature")
+	assert.False(t, repo.VerifyMirror())
+	assert.Equal(t, MirrorVerifyStatus{
+		Missing:   []string{"refs/heads/feature"},
+		Extra:     []string{"refs/heads/old"},
+		Divergent: []string{"refs/heads/main"},
+	}, repo.status.Verify)
+
+	mirrorNoDelete = true
+	assert.False(t, repo.VerifyMirror())
+	assert.Empty(t, repo.status.Verify.Extra)
+
+	repo.mirrorURL = filepath.Join(t.TempDir(), "missing.git")
+	assert.False(t, repo.VerifyMirror())
+	assert.True(t, repo.status.Error)
+	assert.False(t, repo.consistent())
+}
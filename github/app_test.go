@@ -0,0 +1,136 @@
+//go:build !integration
+// +build !integration
+
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func newTestApp(t *testing.T) *appCredentials {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return &appCredentials{
+		appID:          "12345",
+		installationID: "678",
+		privateKey:     key,
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	app := newTestApp(t)
+
+	pkcs1 := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(app.privateKey),
+	})
+	key, err := parsePrivateKey(pkcs1)
+	assert.NoError(t, err)
+	assert.True(t, app.privateKey.Equal(key))
+
+	pkcs8Bytes, err := x509.MarshalPKCS8PrivateKey(app.privateKey)
+	require.NoError(t, err)
+	pkcs8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes})
+	key, err = parsePrivateKey(pkcs8)
+	assert.NoError(t, err)
+	assert.True(t, app.privateKey.Equal(key))
+
+	_, err = parsePrivateKey([]byte("not a key"))
+	assert.Error(t, err)
+}
+
+func TestAppJWT(t *testing.T) {
+	app := newTestApp(t)
+	now := time.Unix(1700000000, 0)
+
+	jwt, err := app.appJWT(now)
+	require.NoError(t, err)
+
+	parts := strings.Split(jwt, ".")
+	require.Len(t, parts, 3)
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"alg": "RS256", "typ": "JWT"}`, string(header))
+
+	claimsData, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]interface{}
+	require.NoError(t, json.Unmarshal(claimsData, &claims))
+	assert.Equal(t, "12345", claims["iss"])
+	assert.Equal(t, float64(now.Unix()-60), claims["iat"])
+	assert.Equal(t, float64(now.Add(9*time.Minute).Unix()), claims["exp"])
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&app.privateKey.PublicKey, crypto.SHA256, digest[:], signature))
+}
+
+func TestInstallationTokenSource(t *testing.T) {
+	app := newTestApp(t)
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/app/installations/678/access_tokens", r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Bearer "))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": "ghs_installation", "expires_at": "%s"}`, expiresAt.Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	source := oauth2.ReuseTokenSource(nil, &installationTokenSource{
+		app:        app,
+		apiBaseURL: server.URL + "/",
+		client:     server.Client(),
+	})
+
+	token, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation", token.AccessToken)
+	assert.True(t, expiresAt.Equal(token.Expiry))
+
+	// token is reused until it expires
+	_, err = source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+}
+
+func TestInstallationTokenSource_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	}))
+	defer server.Close()
+
+	source := &installationTokenSource{
+		app:        newTestApp(t),
+		apiBaseURL: server.URL,
+		client:     server.Client(),
+	}
+
+	_, err := source.Token()
+	assert.ErrorContains(t, err, "installation '678'")
+	assert.ErrorContains(t, err, "404")
+}
@@ -25,80 +25,331 @@ package github
 // UPDATE_HERE
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/google/go-github/v81/github"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
+
+	"github.com/isindir/git-get/credentials"
+	"github.com/isindir/git-get/metadata"
+	"github.com/isindir/git-get/transport"
 )
 
+// publicHost - github.com host, any other host is treated as GitHub Enterprise Server
+const publicHost = "github.com"
+
 type GitGetGithub struct {
-	token string
+	token    string
+	caBundle string
+	// GitHub App authentication, used instead of token when configured
+	app            *appCredentials
+	appTokenSource oauth2.TokenSource
+	// API clients are created once per host and shared by concurrent calls
+	mutex      sync.Mutex
+	httpClient *http.Client
+	clients    map[string]*github.Client
+}
+
+// TeamRepositories - repositories of a single team, where Path is the team slug
+// prefixed by the slugs of its parent teams (e.g. 'platform/sre')
+type TeamRepositories struct {
+	Path         string
+	Repositories []*github.Repository
 }
 
 type GitGetGithubI interface {
 	Init() bool
-	RepositoryExists(ctx context.Context, repositorySha, owner, repository string) bool
+	InitForHost(host string) bool
+	InitTokenForHost(host string) bool
+	SetCABundle(caBundle string)
+	Token(ctx context.Context, repositorySha, baseURL string) (string, error)
+	RepositoryExists(ctx context.Context, repositorySha, baseURL, owner, repository string) bool
 	CreateRepository(
 		ctx context.Context,
 		repositorySha string,
+		baseURL string,
+		owner string,
 		repository string,
 		mirrorVisibilityMode string,
 		sourceURL string,
 	) *github.Repository
+	ValidateTeam(ctx context.Context, repositorySha, baseURL, org, teamSlug string)
+	TeamHasRepository(
+		ctx context.Context,
+		repositorySha, baseURL, org, teamSlug, repository string,
+	) (bool, error)
+	AddTeamRepository(
+		ctx context.Context,
+		repositorySha, baseURL, org, teamSlug, repository, permission string,
+	) error
+	GetRepositoryMetadata(
+		ctx context.Context,
+		repositorySha, baseURL, owner, repository string,
+	) (metadata.Repository, error)
+	UpdateRepositoryMetadata(
+		ctx context.Context,
+		repositorySha, baseURL, owner, repository string,
+		current, desired metadata.Repository,
+	) error
 	FetchOwnerRepos(
 		ctx context.Context,
-		repoSha, owner, githubVisibility, githubAffiliation string,
+		repoSha, baseURL, owner, githubVisibility, githubAffiliation string,
 	) []*github.Repository
+	FetchTeamRepos(ctx context.Context, repoSha, baseURL, owner, team string) []TeamRepositories
 }
 
+// Init - reads credentials for github.com, see InitForHost
 func (gitProvider *GitGetGithub) Init() bool {
-	var tokenFound bool
-	gitProvider.token, tokenFound = os.LookupEnv("GITHUB_TOKEN")
-	if !tokenFound {
-		log.Fatal("Error - environment variable GITHUB_TOKEN not found")
+	return gitProvider.InitForHost(publicHost)
+}
+
+// InitForHost - reads credentials: GitHub App (GITHUB_APP_ID, GITHUB_APP_PRIVATE_KEY_FILE
+// and GITHUB_APP_INSTALLATION_ID) if GITHUB_APP_ID is set, otherwise classic or fine-grained
+// token of the host from credentials hosts file or GITHUB_TOKEN
+func (gitProvider *GitGetGithub) InitForHost(host string) bool {
+	if appID, appFound := os.LookupEnv("GITHUB_APP_ID"); appFound {
+		return gitProvider.initApp(appID)
+	}
+
+	return gitProvider.InitTokenForHost(host)
+}
+
+// InitTokenForHost - reads token of the host from credentials hosts file or GITHUB_TOKEN, GitHub App
+// environment is ignored, as App installation belongs to the owner of the mirror or config-gen host
+func (gitProvider *GitGetGithub) InitTokenForHost(host string) bool {
+	hostCredentials, err := credentials.Lookup(host, "", "GITHUB_TOKEN")
+	if err != nil {
+		log.Fatalf("Error - GitHub credentials for '%s': %s", host, err)
 		os.Exit(1)
 	}
+	gitProvider.token = hostCredentials.Token
 
-	return tokenFound
+	return true
 }
 
-func (gitProvider *GitGetGithub) auth(ctx context.Context, repositorySha string) *github.Client {
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: gitProvider.token},
-	)
-	tc := oauth2.NewClient(ctx, ts)
+func (gitProvider *GitGetGithub) initApp(appID string) bool {
+	privateKeyFile, keyFound := os.LookupEnv("GITHUB_APP_PRIVATE_KEY_FILE")
+	if !keyFound {
+		log.Fatal("Error - environment variable GITHUB_APP_PRIVATE_KEY_FILE not found")
+		os.Exit(1)
+	}
+	installationID, installationFound := os.LookupEnv("GITHUB_APP_INSTALLATION_ID")
+	if !installationFound {
+		log.Fatal("Error - environment variable GITHUB_APP_INSTALLATION_ID not found")
+		os.Exit(1)
+	}
+
+	keyData, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		log.Fatalf("Error - while reading GitHub App private key '%s': %s", privateKeyFile, err)
+		os.Exit(1)
+	}
+	privateKey, err := parsePrivateKey(keyData)
+	if err != nil {
+		log.Fatalf("Error - while parsing GitHub App private key '%s': %s", privateKeyFile, err)
+		os.Exit(1)
+	}
+
+	gitProvider.app = &appCredentials{
+		appID:          appID,
+		installationID: installationID,
+		privateKey:     privateKey,
+	}
+
+	return true
+}
+
+// SetCABundle - sets path to PEM encoded CA certificates bundle, which is used in addition to
+// system certificates to verify GitHub Enterprise Server TLS certificate
+func (gitProvider *GitGetGithub) SetCABundle(caBundle string) {
+	gitProvider.mutex.Lock()
+	defer gitProvider.mutex.Unlock()
+
+	gitProvider.caBundle = caBundle
+	gitProvider.httpClient = nil
+	gitProvider.clients = nil
+}
+
+// IsEnterprise - returns true if host is not public GitHub
+func IsEnterprise(baseURL string) bool {
+	return baseURL != "" && baseURL != publicHost && baseURL != "api."+publicHost
+}
+
+func apiBaseURL(baseURL string) string {
+	if IsEnterprise(baseURL) {
+		return fmt.Sprintf("https://%s/api/v3/", baseURL)
+	}
+
+	return fmt.Sprintf("https://api.%s/", publicHost)
+}
+
+func isForbidden(err error) bool {
+	var errorResponse *github.ErrorResponse
+	return errors.As(err, &errorResponse) &&
+		errorResponse.Response != nil &&
+		errorResponse.Response.StatusCode == http.StatusForbidden
+}
+
+// permissionError - describes API error, for 403 responses adds scopes or permissions
+// GitHub reports as required by the endpoint
+func permissionError(err error) string {
+	if !isForbidden(err) {
+		return err.Error()
+	}
+
+	var errorResponse *github.ErrorResponse
+	errors.As(err, &errorResponse)
+	header := errorResponse.Response.Header
+
+	var details []string
+	if scopes := header.Get("X-Accepted-OAuth-Scopes"); scopes != "" {
+		details = append(details, fmt.Sprintf("required token scopes: '%s'", scopes))
+	}
+	if scopes := header.Get("X-OAuth-Scopes"); scopes != "" {
+		details = append(details, fmt.Sprintf("token scopes: '%s'", scopes))
+	}
+	if permissions := header.Get("X-Accepted-GitHub-Permissions"); permissions != "" {
+		details = append(details, fmt.Sprintf("required fine-grained token or GitHub App permissions: '%s'", permissions))
+	}
+	if len(details) == 0 {
+		details = append(details, "check token scopes or GitHub App permissions")
+	}
+
+	return fmt.Sprintf("%s (insufficient permissions, %s)", err, strings.Join(details, ", "))
+}
+
+func (gitProvider *GitGetGithub) caBundleTLSConfig(repositorySha string) *tls.Config {
+	certPool, err := x509.SystemCertPool()
+	if err != nil {
+		log.Debugf("%s: Can't load system certificates, using only CA bundle: %s", repositorySha, err)
+		certPool = x509.NewCertPool()
+	}
+
+	caData, err := os.ReadFile(gitProvider.caBundle)
+	if err != nil {
+		log.Fatalf("%s: Error - while reading CA bundle '%s': %s", repositorySha, gitProvider.caBundle, err)
+		os.Exit(1)
+	}
+	if !certPool.AppendCertsFromPEM(caData) {
+		log.Fatalf("%s: Error - no PEM certificates found in CA bundle '%s'", repositorySha, gitProvider.caBundle)
+		os.Exit(1)
+	}
+
+	return &tls.Config{
+		RootCAs:    certPool,
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+// tokenSource - returns installation token source shared between API calls for GitHub App,
+// so that installation token is only requested again when it expires, mutex must be held by caller
+func (gitProvider *GitGetGithub) tokenSource(httpClient *http.Client, baseURL string) oauth2.TokenSource {
+	if gitProvider.app == nil {
+		return oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: gitProvider.token},
+		)
+	}
+
+	if gitProvider.appTokenSource == nil {
+		gitProvider.appTokenSource = oauth2.ReuseTokenSource(nil, &installationTokenSource{
+			app:        gitProvider.app,
+			apiBaseURL: apiBaseURL(baseURL),
+			client:     httpClient,
+		})
+	}
+
+	return gitProvider.appTokenSource
+}
+
+// auth - returns API client for the host, client is created on first use and reused afterwards
+func (gitProvider *GitGetGithub) auth(ctx context.Context, repositorySha, baseURL string) *github.Client {
+	gitProvider.mutex.Lock()
+	defer gitProvider.mutex.Unlock()
+
+	if git, found := gitProvider.clients[baseURL]; found {
+		return git
+	}
+
+	if gitProvider.httpClient == nil {
+		if gitProvider.caBundle != "" {
+			gitProvider.httpClient = transport.ClientWithTLS(gitProvider.caBundleTLSConfig(repositorySha))
+		} else {
+			gitProvider.httpClient = transport.Client()
+		}
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, gitProvider.httpClient)
+
+	tc := oauth2.NewClient(ctx, gitProvider.tokenSource(gitProvider.httpClient, baseURL))
 	git := github.NewClient(tc)
 
+	if IsEnterprise(baseURL) {
+		var err error
+		git, err = git.WithEnterpriseURLs(
+			apiBaseURL(baseURL),
+			fmt.Sprintf("https://%s/api/uploads/", baseURL),
+		)
+		if err != nil {
+			log.Fatalf("%s: Error - while trying to configure GitHub Enterprise Server '%s': %s", repositorySha, baseURL, err)
+			os.Exit(1)
+		}
+	}
+
+	if gitProvider.clients == nil {
+		gitProvider.clients = map[string]*github.Client{}
+	}
+	gitProvider.clients[baseURL] = git
+
 	return git
 }
 
+// Token - returns API token of the host, GitHub App installation token is requested again when it expires
+func (gitProvider *GitGetGithub) Token(ctx context.Context, repositorySha, baseURL string) (string, error) {
+	// makes sure http client used for installation token requests is set
+	gitProvider.auth(ctx, repositorySha, baseURL)
+
+	gitProvider.mutex.Lock()
+	defer gitProvider.mutex.Unlock()
+
+	token, err := gitProvider.tokenSource(gitProvider.httpClient, baseURL).Token()
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
 // RepositoryExists - check if remote github repository exists (method)
-func (gitProvider *GitGetGithub) RepositoryExists(ctx context.Context, repositorySha, owner, repository string) bool {
-	git := gitProvider.auth(ctx, repositorySha)
+func (gitProvider *GitGetGithub) RepositoryExists(
+	ctx context.Context,
+	repositorySha, baseURL, owner, repository string,
+) bool {
+	git := gitProvider.auth(ctx, repositorySha, baseURL)
 	repo, _, err := git.Repositories.Get(ctx, owner, repository)
 
 	log.Debugf("%s: %+v == %+v", repositorySha, repo, err)
 	return err == nil
 }
 
-// RepositoryExists - check if remote github repository exists (package function for backward compatibility)
-func RepositoryExists(ctx context.Context, repositorySha, owner, repository string) bool {
-	gitProvider := &GitGetGithub{}
-	gitProvider.Init()
-	return gitProvider.RepositoryExists(ctx, repositorySha, owner, repository)
-}
-
-// CreateRepository - Create github repository (method)
+// CreateRepository - Create github repository in organization or for authenticated user (method),
+// empty owner means authenticated user
 func (gitProvider *GitGetGithub) CreateRepository(
 	ctx context.Context,
 	repositorySha string,
+	baseURL string,
+	owner string,
 	repository string,
 	mirrorVisibilityMode string,
 	sourceURL string,
 ) *github.Repository {
-	git := gitProvider.auth(ctx, repositorySha)
+	git := gitProvider.auth(ctx, repositorySha, baseURL)
 	isPrivate := true
 
 	if mirrorVisibilityMode == "public" {
@@ -111,28 +362,208 @@ func (gitProvider *GitGetGithub) CreateRepository(
 		Description: github.Ptr(fmt.Sprintf("Mirror of the '%s'", sourceURL)),
 	}
 
-	resultingRepository, _, err := git.Repositories.Create(ctx, "", repoDef)
+	org := gitProvider.repositoryOrg(ctx, git, repositorySha, owner)
+	resultingRepository, _, err := git.Repositories.Create(ctx, org, repoDef)
 	if err != nil {
 		log.Fatalf(
-			"%s: Error - while trying to create github repository '%s': '%s'",
-			repositorySha, repository, err)
+			"%s: Error - while trying to create github repository '%s/%s': '%s'",
+			repositorySha, owner, repository, permissionError(err))
 		os.Exit(1)
 	}
 
 	return resultingRepository
 }
 
-// CreateRepository - Create github repository (package function for backward compatibility)
-func CreateRepository(
+// repositoryOrg - returns organization to create repository in, or empty string if repository is
+// created for authenticated user, repositories can't be created for other users
+func (gitProvider *GitGetGithub) repositoryOrg(
 	ctx context.Context,
-	repositorySha string,
-	repository string,
-	mirrorVisibilityMode string,
-	sourceURL string,
-) *github.Repository {
-	gitProvider := &GitGetGithub{}
-	gitProvider.Init()
-	return gitProvider.CreateRepository(ctx, repositorySha, repository, mirrorVisibilityMode, sourceURL)
+	git *github.Client,
+	repositorySha, owner string,
+) string {
+	if owner == "" {
+		return ""
+	}
+
+	user, _, err := git.Users.Get(ctx, owner)
+	if err != nil {
+		log.Fatalf("%s: Error - while trying to get github owner '%s': '%s'", repositorySha, owner, permissionError(err))
+		os.Exit(1)
+	}
+	log.Debugf("%s: Owner '%s', Type: '%s'", repositorySha, owner, user.GetType())
+
+	if user.GetType() == "Organization" {
+		return owner
+	}
+
+	// GitHub App installation tokens can't read authenticated user
+	if gitProvider.app == nil {
+		authenticated, _, err := git.Users.Get(ctx, "")
+		if err == nil && !strings.EqualFold(authenticated.GetLogin(), owner) {
+			log.Fatalf(
+				"%s: Error - github repository can't be created for user '%s', token belongs to '%s'",
+				repositorySha, owner, authenticated.GetLogin())
+			os.Exit(1)
+		}
+	}
+
+	return ""
+}
+
+// ValidateTeam - ensures owner is an organization and the team exists in it, so that team permission
+// can be granted on repositories of the owner
+func (gitProvider *GitGetGithub) ValidateTeam(ctx context.Context, repositorySha, baseURL, org, teamSlug string) {
+	git := gitProvider.auth(ctx, repositorySha, baseURL)
+
+	user, _, err := git.Users.Get(ctx, org)
+	if err != nil {
+		log.Fatalf("%s: Error - while trying to get github owner '%s': '%s'", repositorySha, org, permissionError(err))
+		os.Exit(1)
+	}
+	if user.GetType() != "Organization" {
+		log.Fatalf(
+			"%s: Error - team '%s' permission can't be granted, github owner '%s' is not an organization",
+			repositorySha, teamSlug, org)
+		os.Exit(1)
+	}
+
+	if _, _, err := git.Teams.GetTeamBySlug(ctx, org, teamSlug); err != nil {
+		log.Fatalf(
+			"%s: Error - while trying to get team '%s' of github organization '%s': '%s'",
+			repositorySha, teamSlug, org, permissionError(err))
+		os.Exit(1)
+	}
+}
+
+// TeamHasRepository - returns true if organization team has access to the repository
+func (gitProvider *GitGetGithub) TeamHasRepository(
+	ctx context.Context,
+	repositorySha, baseURL, org, teamSlug, repository string,
+) (bool, error) {
+	git := gitProvider.auth(ctx, repositorySha, baseURL)
+
+	_, res, err := git.Teams.IsTeamRepoBySlug(ctx, org, teamSlug, org, repository)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, errors.New(permissionError(err))
+	}
+
+	return true, nil
+}
+
+// AddTeamRepository - grants organization team permission on the repository, permission is one of
+// pull, triage, push, maintain, admin or organization custom repository role
+func (gitProvider *GitGetGithub) AddTeamRepository(
+	ctx context.Context,
+	repositorySha, baseURL, org, teamSlug, repository, permission string,
+) error {
+	git := gitProvider.auth(ctx, repositorySha, baseURL)
+	log.Debugf("%s: Granting team '%s' '%s' permission on '%s/%s'", repositorySha, teamSlug, permission, org, repository)
+
+	_, err := git.Teams.AddTeamRepoBySlug(
+		ctx, org, teamSlug, org, repository,
+		&github.TeamAddTeamRepoOptions{Permission: permission},
+	)
+	if err != nil {
+		return errors.New(permissionError(err))
+	}
+
+	return nil
+}
+
+// GetRepositoryMetadata - returns metadata of github repository
+func (gitProvider *GitGetGithub) GetRepositoryMetadata(
+	ctx context.Context,
+	repositorySha, baseURL, owner, repository string,
+) (metadata.Repository, error) {
+	git := gitProvider.auth(ctx, repositorySha, baseURL)
+	repo, _, err := git.Repositories.Get(ctx, owner, repository)
+	if err != nil {
+		return metadata.Repository{}, errors.New(permissionError(err))
+	}
+
+	visibility := repo.GetVisibility()
+	if visibility == "" {
+		visibility = "public"
+		if repo.GetPrivate() {
+			visibility = "private"
+		}
+	}
+
+	return metadata.Repository{
+		Description:   repo.GetDescription(),
+		Homepage:      repo.GetHomepage(),
+		Topics:        repo.Topics,
+		DefaultBranch: repo.GetDefaultBranch(),
+		Visibility:    visibility,
+		Archived:      repo.GetArchived(),
+	}, nil
+}
+
+// UpdateRepositoryMetadata - updates github repository fields which differ from desired metadata,
+// archived repository is read-only, so it is unarchived before and archived after other changes
+func (gitProvider *GitGetGithub) UpdateRepositoryMetadata(
+	ctx context.Context,
+	repositorySha, baseURL, owner, repository string,
+	current, desired metadata.Repository,
+) error {
+	if current.Archived && desired.Archived {
+		log.Debugf("%s: github repository '%s/%s' is archived, skipping metadata update", repositorySha, owner, repository)
+		return nil
+	}
+
+	git := gitProvider.auth(ctx, repositorySha, baseURL)
+	edit := &github.Repository{}
+	changed := false
+	if current.Archived {
+		edit.Archived = github.Ptr(false)
+		changed = true
+	}
+	if current.Description != desired.Description {
+		edit.Description = github.Ptr(desired.Description)
+		changed = true
+	}
+	if current.Homepage != desired.Homepage {
+		edit.Homepage = github.Ptr(desired.Homepage)
+		changed = true
+	}
+	if desired.DefaultBranch != "" && current.DefaultBranch != desired.DefaultBranch {
+		edit.DefaultBranch = github.Ptr(desired.DefaultBranch)
+		changed = true
+	}
+	if desired.Visibility != "" && current.Visibility != desired.Visibility {
+		edit.Visibility = github.Ptr(desired.Visibility)
+		changed = true
+	}
+
+	if changed {
+		log.Debugf("%s: Updating github repository '%s/%s' metadata", repositorySha, owner, repository)
+		if _, _, err := git.Repositories.Edit(ctx, owner, repository, edit); err != nil {
+			return errors.New(permissionError(err))
+		}
+	}
+
+	if !metadata.SameTopics(current.Topics, desired.Topics) {
+		log.Debugf("%s: Updating github repository '%s/%s' topics", repositorySha, owner, repository)
+		topics := desired.Topics
+		if topics == nil {
+			topics = []string{}
+		}
+		if _, _, err := git.Repositories.ReplaceAllTopics(ctx, owner, repository, topics); err != nil {
+			return errors.New(permissionError(err))
+		}
+	}
+
+	if desired.Archived {
+		log.Debugf("%s: Archiving github repository '%s/%s'", repositorySha, owner, repository)
+		if _, _, err := git.Repositories.Edit(ctx, owner, repository, &github.Repository{Archived: github.Ptr(true)}); err != nil {
+			return errors.New(permissionError(err))
+		}
+	}
+
+	return nil
 }
 
 func fetchOrgRepos(
@@ -150,6 +581,11 @@ func fetchOrgRepos(
 
 	for {
 		repos, res, err := git.Repositories.ListByOrg(ctx, owner, opts)
+		if err != nil {
+			checkForbidden(repoSha, owner, err)
+			log.Debugf("%s: Error fetching repositories for '%s': %+v\n", repoSha, owner, err)
+			break
+		}
 		log.Debugf(
 			"%s: NextPage/PrevPage/FirstPage/LastPage '%d/%d/%d/%d'\n",
 			repoSha, res.NextPage, res.PrevPage, res.FirstPage, res.LastPage)
@@ -166,11 +602,50 @@ func fetchOrgRepos(
 		if res.NextPage == 0 {
 			break
 		}
+	}
+
+	return repoList
+}
+
+// checkForbidden - fails with explanation of missing permissions on 403 response
+func checkForbidden(repoSha, owner string, err error) {
+	if isForbidden(err) {
+		log.Fatalf("%s: Error - while fetching repositories for '%s': %s", repoSha, owner, permissionError(err))
+		os.Exit(1)
+	}
+}
+
+// fetchInstallationRepos - GitHub App installation can't list repositories of authenticated user,
+// instead repositories the installation has access to are listed and filtered by owner
+func fetchInstallationRepos(
+	ctx context.Context,
+	git *github.Client,
+	repoSha, owner string,
+) []*github.Repository {
+	var repoList []*github.Repository
+
+	opts := &github.ListOptions{
+		Page: 0,
+	}
 
+	for {
+		repos, res, err := git.Apps.ListRepos(ctx, opts)
 		if err != nil {
-			log.Debugf("%s: Error fetching repositories for '%s': %+v\n", repoSha, owner, err)
+			checkForbidden(repoSha, owner, err)
+			log.Debugf("%s: Error fetching installation repositories for '%s': %+v\n", repoSha, owner, err)
 			break
 		}
+		for repo := 0; repo < len(repos.Repositories); repo++ {
+			if strings.EqualFold(repos.Repositories[repo].GetOwner().GetLogin(), owner) {
+				log.Debugf("%s: (%d) Repo FullName '%s'", repoSha, opts.Page, repos.Repositories[repo].GetFullName())
+				repoList = append(repoList, repos.Repositories[repo])
+			}
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+		opts.Page = res.NextPage
 	}
 
 	return repoList
@@ -197,6 +672,11 @@ func fetchUserRepos(
 
 	for {
 		repos, res, err := git.Repositories.ListByAuthenticatedUser(ctx, opts)
+		if err != nil {
+			checkForbidden(repoSha, owner, err)
+			log.Debugf("%s: Error fetching repositories for '%s': %+v\n", repoSha, owner, err)
+			break
+		}
 		log.Debugf(
 			"%s: NextPage/PrevPage/FirstPage/LastPage '%d/%d/%d/%d'\n",
 			repoSha, res.NextPage, res.PrevPage, res.FirstPage, res.LastPage)
@@ -213,11 +693,6 @@ func fetchUserRepos(
 		if res.NextPage == 0 {
 			break
 		}
-
-		if err != nil {
-			log.Debugf("%s: Error fetching repositories for '%s': %+v\n", repoSha, owner, err)
-			break
-		}
 	}
 
 	return repoList
@@ -226,15 +701,16 @@ func fetchUserRepos(
 // FetchOwnerRepos - fetch owner repositories via API, being it Organization or User (method)
 func (gitProvider *GitGetGithub) FetchOwnerRepos(
 	ctx context.Context,
-	repoSha, owner, githubVisibility, githubAffiliation string,
+	repoSha, baseURL, owner, githubVisibility, githubAffiliation string,
 ) []*github.Repository {
 	log.Debugf("%s: Specified owner: '%s'", repoSha, owner)
-	git := gitProvider.auth(ctx, repoSha)
+	git := gitProvider.auth(ctx, repoSha, baseURL)
 	var repoList []*github.Repository
 	var userType string
 
 	user, _, err := git.Users.Get(ctx, owner)
 	if err != nil {
+		checkForbidden(repoSha, owner, err)
 		log.Debugf("%s: Owner '%s' not found: '%+v'", repoSha, owner, err)
 	} else {
 		log.Debugf("%s: Owner '%s', Type: '%s'", repoSha, owner, *user.Type)
@@ -245,7 +721,11 @@ func (gitProvider *GitGetGithub) FetchOwnerRepos(
 	case "Organization":
 		repoList = fetchOrgRepos(ctx, git, repoSha, owner)
 	case "User":
-		repoList = fetchUserRepos(ctx, git, repoSha, owner, githubVisibility, githubAffiliation)
+		if gitProvider.app != nil {
+			repoList = fetchInstallationRepos(ctx, git, repoSha, owner)
+		} else {
+			repoList = fetchUserRepos(ctx, git, repoSha, owner, githubVisibility, githubAffiliation)
+		}
 	default:
 		log.Fatalf("%s: Error: unknown '%s' user type", repoSha, userType)
 		os.Exit(1)
@@ -254,12 +734,146 @@ func (gitProvider *GitGetGithub) FetchOwnerRepos(
 	return repoList
 }
 
-// FetchOwnerRepos - fetch owner repositories via API, being it Organization or User (package function for backward compatibility)
-func FetchOwnerRepos(
+func fetchSingleTeamRepos(
 	ctx context.Context,
-	repoSha, owner, githubVisibility, githubAffiliation string,
-) []*github.Repository {
-	gitProvider := &GitGetGithub{}
-	gitProvider.Init()
-	return gitProvider.FetchOwnerRepos(ctx, repoSha, owner, githubVisibility, githubAffiliation)
+	git *github.Client,
+	repoSha, owner, teamSlug string,
+) ([]*github.Repository, error) {
+	var repoList []*github.Repository
+
+	opts := &github.ListOptions{
+		Page: 0,
+	}
+
+	for {
+		repos, res, err := git.Teams.ListTeamReposBySlug(ctx, owner, teamSlug, opts)
+		if err != nil {
+			return nil, fmt.Errorf("fetching repositories for team '%s/%s': %s", owner, teamSlug, permissionError(err))
+		}
+		for repo := 0; repo < len(repos); repo++ {
+			log.Debugf("%s: (%d) Team '%s' Repo FullName '%s'", repoSha, opts.Page, teamSlug, *repos[repo].FullName)
+		}
+		repoList = append(repoList, repos...)
+
+		if res.NextPage == 0 {
+			break
+		}
+		opts.Page = res.NextPage
+	}
+
+	return repoList, nil
+}
+
+func fetchChildTeams(
+	ctx context.Context,
+	git *github.Client,
+	repoSha, owner, teamSlug string,
+) ([]*github.Team, error) {
+	var teamList []*github.Team
+
+	opts := &github.ListOptions{
+		Page: 0,
+	}
+
+	for {
+		teams, res, err := git.Teams.ListChildTeamsByParentSlug(ctx, owner, teamSlug, opts)
+		if err != nil {
+			return nil, fmt.Errorf("fetching child teams for team '%s/%s': %s", owner, teamSlug, permissionError(err))
+		}
+		teamList = append(teamList, teams...)
+
+		if res.NextPage == 0 {
+			break
+		}
+		opts.Page = res.NextPage
+	}
+
+	return teamList, nil
+}
+
+// Recursive function collecting team and nested teams repositories, any API error is returned, as
+// repositories of the team subtree would be silently missing otherwise
+func fetchTeamRepos(
+	ctx context.Context,
+	git *github.Client,
+	repoSha, owner, teamSlug, teamPath string,
+	teamRepoList []TeamRepositories,
+) ([]TeamRepositories, error) {
+	log.Debugf("%s: Fetching repositories for team '%s/%s'", repoSha, owner, teamPath)
+	repos, err := fetchSingleTeamRepos(ctx, git, repoSha, owner, teamSlug)
+	if err != nil {
+		return nil, err
+	}
+	teamRepoList = append(teamRepoList, TeamRepositories{
+		Path:         teamPath,
+		Repositories: repos,
+	})
+
+	childTeams, err := fetchChildTeams(ctx, git, repoSha, owner, teamSlug)
+	if err != nil {
+		return nil, err
+	}
+	for team := 0; team < len(childTeams); team++ {
+		teamRepoList, err = fetchTeamRepos(
+			ctx,
+			git,
+			repoSha,
+			owner,
+			childTeams[team].GetSlug(),
+			fmt.Sprintf("%s/%s", teamPath, childTeams[team].GetSlug()),
+			teamRepoList,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return teamRepoList, nil
+}
+
+// keepDeepestTeam - leaves every repository only in the most nested team it belongs to,
+// so that each repository is cloned into a single team subtree
+func keepDeepestTeam(teamRepoList []TeamRepositories) []TeamRepositories {
+	depth := make(map[string]int)
+	for _, team := range teamRepoList {
+		teamDepth := strings.Count(team.Path, "/")
+		for _, repo := range team.Repositories {
+			if current, found := depth[repo.GetFullName()]; !found || teamDepth > current {
+				depth[repo.GetFullName()] = teamDepth
+			}
+		}
+	}
+
+	result := make([]TeamRepositories, 0, len(teamRepoList))
+	for _, team := range teamRepoList {
+		teamDepth := strings.Count(team.Path, "/")
+		filtered := TeamRepositories{Path: team.Path}
+		for _, repo := range team.Repositories {
+			if depth[repo.GetFullName()] == teamDepth {
+				// same depth in sibling teams - first team wins
+				depth[repo.GetFullName()] = -1
+				filtered.Repositories = append(filtered.Repositories, repo)
+			}
+		}
+		result = append(result, filtered)
+	}
+
+	return result
+}
+
+// FetchTeamRepos - fetch repositories of the organisation team and all nested teams (method)
+func (gitProvider *GitGetGithub) FetchTeamRepos(
+	ctx context.Context,
+	repoSha, baseURL, owner, team string,
+) []TeamRepositories {
+	log.Debugf("%s: Specified owner: '%s', team: '%s'", repoSha, owner, team)
+	git := gitProvider.auth(ctx, repoSha, baseURL)
+
+	teamRepoList, err := fetchTeamRepos(ctx, git, repoSha, owner, team, team, nil)
+	if err != nil {
+		log.Fatalf("%s: Error - while %s", repoSha, err)
+		os.Exit(1)
+	}
+
+	return keepDeepestTeam(teamRepoList)
 }
@@ -5,11 +5,22 @@ package github
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-github/v81/github"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/isindir/git-get/metadata"
 )
 
 func TestGitGetGithub_Init_Success(t *testing.T) {
@@ -101,30 +112,57 @@ func TestGitGetGithub_Auth(t *testing.T) {
 	gitProvider.Init()
 
 	ctx := context.Background()
-	client := gitProvider.auth(ctx, "test-sha")
+	client := gitProvider.auth(ctx, "test-sha", "github.com")
 
 	assert.NotNil(t, client)
 	// Verify client is properly initialized
 	assert.NotNil(t, client.Repositories)
 	assert.NotNil(t, client.Users)
+	assert.Equal(t, "https://api.github.com/", client.BaseURL.String())
 }
 
-func TestRepositoryExists_PackageFunction(t *testing.T) {
-	// This test requires actual GitHub API calls or mocking at HTTP level
-	// Skipping for unit tests
-	t.Skip("Requires GitHub API mocking or integration test")
+func TestGitGetGithub_Auth_Enterprise(t *testing.T) {
+	gitProvider := &GitGetGithub{token: "test-token-123"}
+
+	client := gitProvider.auth(context.Background(), "test-sha", "ghe.corp.example")
+
+	assert.Equal(t, "https://ghe.corp.example/api/v3/", client.BaseURL.String())
+	assert.Equal(t, "https://ghe.corp.example/api/uploads/", client.UploadURL.String())
 }
 
-func TestCreateRepository_PackageFunction(t *testing.T) {
-	// This test requires actual GitHub API calls or mocking at HTTP level
-	// Skipping for unit tests
-	t.Skip("Requires GitHub API mocking or integration test")
+func TestIsEnterprise(t *testing.T) {
+	assert.False(t, IsEnterprise(""))
+	assert.False(t, IsEnterprise("github.com"))
+	assert.False(t, IsEnterprise("api.github.com"))
+	assert.True(t, IsEnterprise("ghe.corp.example"))
 }
 
-func TestFetchOwnerRepos_PackageFunction(t *testing.T) {
-	// This test requires actual GitHub API calls or mocking at HTTP level
-	// Skipping for unit tests
-	t.Skip("Requires GitHub API mocking or integration test")
+func TestRepositoryExists_EnterpriseWithCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token-123", r.Header.Get("Authorization"))
+		if r.URL.Path == "/api/v3/repos/acmeorg/api" {
+			fmt.Fprint(w, `{"id": 1, "name": "api"}`)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	err := os.WriteFile(
+		caBundle,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+		0o600,
+	)
+	assert.NoError(t, err)
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	gitProvider := &GitGetGithub{token: "test-token-123"}
+	gitProvider.SetCABundle(caBundle)
+
+	ctx := context.Background()
+	assert.True(t, gitProvider.RepositoryExists(ctx, "test-sha", host, "acmeorg", "api"))
+	assert.False(t, gitProvider.RepositoryExists(ctx, "test-sha", host, "acmeorg", "missing"))
 }
 
 func TestGitGetGithub_CreateRepository_PrivateMode(t *testing.T) {
@@ -139,6 +177,110 @@ func TestGitGetGithub_CreateRepository_PublicMode(t *testing.T) {
 	t.Skip("Requires GitHub client mocking")
 }
 
+func TestGitGetGithub_CreateRepository_Owner(t *testing.T) {
+	var created []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/acmeorg", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login":"acmeorg","type":"Organization"}`)
+	})
+	mux.HandleFunc("/users/johndoe", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login":"johndoe","type":"User"}`)
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login":"JohnDoe","type":"User"}`)
+	})
+	createHandler := func(w http.ResponseWriter, r *http.Request) {
+		var repo map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&repo))
+		created = append(created, fmt.Sprintf("%s %s private=%v", r.URL.Path, repo["name"], repo["private"]))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	}
+	mux.HandleFunc("/orgs/acmeorg/repos", createHandler)
+	mux.HandleFunc("/user/repos", createHandler)
+
+	gitProvider := &GitGetGithub{clients: map[string]*github.Client{publicHost: newTestClient(t, mux)}}
+	ctx := context.Background()
+
+	gitProvider.CreateRepository(ctx, "test-sha", publicHost, "acmeorg", "api", "private", "git@gitlab.com:acme/api.git")
+	gitProvider.CreateRepository(ctx, "test-sha", publicHost, "johndoe", "web", "public", "git@gitlab.com:acme/web.git")
+
+	assert.Equal(t, []string{
+		"/orgs/acmeorg/repos api private=true",
+		"/user/repos web private=false",
+	}, created)
+}
+
+func TestGitGetGithub_AddTeamRepository(t *testing.T) {
+	var permission string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/acmeorg/teams/platform/repos/acmeorg/api", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		var options map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+		permission = options["permission"]
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/orgs/acmeorg/teams/platform/repos/acmeorg/web", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Must have admin rights to Repository."}`))
+	})
+
+	gitProvider := &GitGetGithub{clients: map[string]*github.Client{publicHost: newTestClient(t, mux)}}
+	ctx := context.Background()
+
+	assert.NoError(t, gitProvider.AddTeamRepository(ctx, "test-sha", publicHost, "acmeorg", "platform", "api", "maintain"))
+	assert.Equal(t, "maintain", permission)
+	assert.Error(t, gitProvider.AddTeamRepository(ctx, "test-sha", publicHost, "acmeorg", "platform", "web", "maintain"))
+}
+
+func TestGitGetGithub_TeamHasRepository(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/acmeorg/teams/platform/repos/acmeorg/api", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		_, _ = w.Write([]byte(`{"name":"api"}`))
+	})
+	mux.HandleFunc("/orgs/acmeorg/teams/platform/repos/acmeorg/web", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/orgs/acmeorg/teams/platform/repos/acmeorg/db", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	gitProvider := &GitGetGithub{clients: map[string]*github.Client{publicHost: newTestClient(t, mux)}}
+	ctx := context.Background()
+
+	hasAccess, err := gitProvider.TeamHasRepository(ctx, "test-sha", publicHost, "acmeorg", "platform", "api")
+	assert.NoError(t, err)
+	assert.True(t, hasAccess)
+
+	hasAccess, err = gitProvider.TeamHasRepository(ctx, "test-sha", publicHost, "acmeorg", "platform", "web")
+	assert.NoError(t, err)
+	assert.False(t, hasAccess)
+
+	_, err = gitProvider.TeamHasRepository(ctx, "test-sha", publicHost, "acmeorg", "platform", "db")
+	assert.Error(t, err)
+}
+
+func TestGitGetGithub_ValidateTeam(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/acmeorg", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		_, _ = w.Write([]byte(`{"login":"acmeorg","type":"Organization"}`))
+	})
+	mux.HandleFunc("/orgs/acmeorg/teams/platform", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		_, _ = w.Write([]byte(`{"slug":"platform"}`))
+	})
+
+	gitProvider := &GitGetGithub{clients: map[string]*github.Client{publicHost: newTestClient(t, mux)}}
+	gitProvider.ValidateTeam(context.Background(), "test-sha", publicHost, "acmeorg", "platform")
+
+	assert.Equal(t, []string{"/users/acmeorg", "/orgs/acmeorg/teams/platform"}, requests)
+}
+
 func TestGithubPtr(t *testing.T) {
 	// Test github.Ptr helper function behavior
 	strVal := "test"
@@ -151,3 +293,211 @@ func TestGithubPtr(t *testing.T) {
 	assert.NotNil(t, boolPtr)
 	assert.Equal(t, true, *boolPtr)
 }
+
+func newTestClient(t *testing.T, handler http.Handler) *github.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	assert.NoError(t, err)
+	client.BaseURL = baseURL
+
+	return client
+}
+
+func TestFetchTeamRepos(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/acme/teams/platform/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"full_name":"acme/infra"},{"full_name":"acme/monitoring"}]`)
+	})
+	mux.HandleFunc("/orgs/acme/teams/platform/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"slug":"sre"}]`)
+	})
+	mux.HandleFunc("/orgs/acme/teams/sre/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"full_name":"acme/monitoring"},{"full_name":"acme/alerts"}]`)
+	})
+	mux.HandleFunc("/orgs/acme/teams/sre/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	client := newTestClient(t, mux)
+	teamRepoList, err := fetchTeamRepos(context.Background(), client, "test-sha", "acme", "platform", "platform", nil)
+	assert.NoError(t, err)
+	teamRepoList = keepDeepestTeam(teamRepoList)
+
+	assert.Len(t, teamRepoList, 2)
+	assert.Equal(t, "platform", teamRepoList[0].Path)
+	assert.Len(t, teamRepoList[0].Repositories, 1)
+	assert.Equal(t, "acme/infra", teamRepoList[0].Repositories[0].GetFullName())
+	assert.Equal(t, "platform/sre", teamRepoList[1].Path)
+	assert.Len(t, teamRepoList[1].Repositories, 2)
+	assert.Equal(t, "acme/monitoring", teamRepoList[1].Repositories[0].GetFullName())
+	assert.Equal(t, "acme/alerts", teamRepoList[1].Repositories[1].GetFullName())
+}
+
+func TestFetchTeamRepos_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/acme/teams/platform/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"full_name":"acme/infra"}]`)
+	})
+	mux.HandleFunc("/orgs/acme/teams/platform/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"slug":"sre"}]`)
+	})
+	mux.HandleFunc("/orgs/acme/teams/sre/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"Resource not accessible by integration"}`)
+	})
+	client := newTestClient(t, mux)
+
+	_, err := fetchTeamRepos(context.Background(), client, "test-sha", "acme", "platform", "platform", nil)
+	assert.ErrorContains(t, err, "team 'acme/sre'")
+
+	// unknown team slug
+	_, err = fetchTeamRepos(context.Background(), client, "test-sha", "acme", "missing", "missing", nil)
+	assert.ErrorContains(t, err, "team 'acme/missing'")
+}
+
+func TestGitGetGithub_RepositoryMetadata(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/api", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `{"description":"API","homepage":"https://acme.com","topics":["go"],`+
+				`"default_branch":"main","private":true,"archived":true}`)
+			return
+		}
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, fmt.Sprintf("%s %v", r.Method, body))
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/repos/acme/api/topics", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, fmt.Sprintf("%s topics %v", r.Method, body["names"]))
+		fmt.Fprint(w, `{"names":[]}`)
+	})
+
+	gitProvider := &GitGetGithub{clients: map[string]*github.Client{publicHost: newTestClient(t, mux)}}
+	ctx := context.Background()
+
+	current, err := gitProvider.GetRepositoryMetadata(ctx, "test-sha", publicHost, "acme", "api")
+	assert.NoError(t, err)
+	assert.Equal(t, metadata.Repository{
+		Description:   "API",
+		Homepage:      "https://acme.com",
+		Topics:        []string{"go"},
+		DefaultBranch: "main",
+		Visibility:    "private",
+		Archived:      true,
+	}, current)
+
+	// archived mirror of the archived source is not changed
+	desired := current
+	desired.Description = "New"
+	assert.NoError(t, gitProvider.UpdateRepositoryMetadata(ctx, "test-sha", publicHost, "acme", "api", current, desired))
+	assert.Empty(t, requests)
+
+	// unarchived source unarchives mirror together with other changes
+	desired.Archived = false
+	desired.Topics = []string{"Go"}
+	assert.NoError(t, gitProvider.UpdateRepositoryMetadata(ctx, "test-sha", publicHost, "acme", "api", current, desired))
+	assert.Equal(t, []string{"PATCH map[archived:false description:New]"}, requests)
+
+	// archived source archives mirror after other changes
+	requests = nil
+	current.Archived = false
+	desired = metadata.Repository{Description: "API", DefaultBranch: "develop", Visibility: "private", Archived: true}
+	assert.NoError(t, gitProvider.UpdateRepositoryMetadata(ctx, "test-sha", publicHost, "acme", "api", current, desired))
+	assert.Equal(t, []string{
+		"PATCH map[default_branch:develop homepage:]",
+		"PUT topics []",
+		"PATCH map[archived:true]",
+	}, requests)
+}
+
+func TestKeepDeepestTeam_SiblingTeams(t *testing.T) {
+	repo := &github.Repository{FullName: github.Ptr("acme/shared")}
+	teamRepoList := keepDeepestTeam([]TeamRepositories{
+		{Path: "platform/a", Repositories: []*github.Repository{repo}},
+		{Path: "platform/b", Repositories: []*github.Repository{repo}},
+	})
+
+	assert.Len(t, teamRepoList[0].Repositories, 1)
+	assert.Empty(t, teamRepoList[1].Repositories)
+}
+
+func TestPermissionError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Accepted-OAuth-Scopes", "public_repo, repo")
+		w.Header().Set("X-OAuth-Scopes", "read:org")
+		w.Header().Set("X-Accepted-GitHub-Permissions", "administration=write")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "Resource not accessible by personal access token"}`)
+	})
+	mux.HandleFunc("/repos/acmeorg/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	client := newTestClient(t, mux)
+	ctx := context.Background()
+
+	_, _, err := client.Repositories.Create(ctx, "", &github.Repository{Name: github.Ptr("api")})
+	assert.True(t, isForbidden(err))
+	message := permissionError(err)
+	assert.Contains(t, message, "required token scopes: 'public_repo, repo'")
+	assert.Contains(t, message, "token scopes: 'read:org'")
+	assert.Contains(t, message, "required fine-grained token or GitHub App permissions: 'administration=write'")
+
+	_, _, err = client.Repositories.Get(ctx, "acmeorg", "missing")
+	assert.False(t, isForbidden(err))
+	assert.Equal(t, err.Error(), permissionError(err))
+}
+
+func TestGitGetGithub_Init_App(t *testing.T) {
+	app := newTestApp(t)
+	keyFile := filepath.Join(t.TempDir(), "app.pem")
+	err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(app.privateKey),
+	}), 0o600)
+	assert.NoError(t, err)
+
+	t.Setenv("GITHUB_APP_ID", "12345")
+	t.Setenv("GITHUB_APP_PRIVATE_KEY_FILE", keyFile)
+	t.Setenv("GITHUB_APP_INSTALLATION_ID", "678")
+
+	gitProvider := &GitGetGithub{}
+	assert.True(t, gitProvider.Init())
+	assert.Equal(t, "12345", gitProvider.app.appID)
+	assert.Equal(t, "678", gitProvider.app.installationID)
+	assert.True(t, app.privateKey.Equal(gitProvider.app.privateKey))
+
+	// installation token source is shared between clients
+	first := gitProvider.tokenSource(http.DefaultClient, "github.com")
+	second := gitProvider.tokenSource(http.DefaultClient, "github.com")
+	assert.Same(t, first, second)
+
+	// token only initialisation ignores GitHub App environment
+	t.Setenv("GITHUB_TOKEN", "test-token-123")
+	tokenProvider := &GitGetGithub{}
+	assert.True(t, tokenProvider.InitTokenForHost("github.com"))
+	assert.Nil(t, tokenProvider.app)
+	token, err := tokenProvider.Token(context.Background(), "test-sha", "github.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "test-token-123", token)
+}
+
+func TestGitGetGithub_Auth_ClientReused(t *testing.T) {
+	gitProvider := &GitGetGithub{token: "test-token-123"}
+	ctx := context.Background()
+
+	first := gitProvider.auth(ctx, "test-sha", "github.com")
+	assert.Same(t, first, gitProvider.auth(ctx, "test-sha", "github.com"))
+	assert.NotSame(t, first, gitProvider.auth(ctx, "test-sha", "ghe.corp.example"))
+
+	// changing CA bundle requires new clients
+	gitProvider.SetCABundle("")
+	assert.NotSame(t, first, gitProvider.auth(ctx, "test-sha", "github.com"))
+}
@@ -8,6 +8,8 @@ import (
 	"context"
 
 	"github.com/google/go-github/v81/github"
+	github1 "github.com/isindir/git-get/github"
+	"github.com/isindir/git-get/metadata"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -38,17 +40,104 @@ func (_m *GitGetGithubI) EXPECT() *GitGetGithubI_Expecter {
 	return &GitGetGithubI_Expecter{mock: &_m.Mock}
 }
 
+// AddTeamRepository provides a mock function for the type GitGetGithubI
+func (_mock *GitGetGithubI) AddTeamRepository(ctx context.Context, repositorySha string, baseURL string, org string, teamSlug string, repository string, permission string) error {
+	ret := _mock.Called(ctx, repositorySha, baseURL, org, teamSlug, repository, permission)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTeamRepository")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, string) error); ok {
+		r0 = returnFunc(ctx, repositorySha, baseURL, org, teamSlug, repository, permission)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// GitGetGithubI_AddTeamRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTeamRepository'
+type GitGetGithubI_AddTeamRepository_Call struct {
+	*mock.Call
+}
+
+// AddTeamRepository is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositorySha string
+//   - baseURL string
+//   - org string
+//   - teamSlug string
+//   - repository string
+//   - permission string
+func (_e *GitGetGithubI_Expecter) AddTeamRepository(ctx interface{}, repositorySha interface{}, baseURL interface{}, org interface{}, teamSlug interface{}, repository interface{}, permission interface{}) *GitGetGithubI_AddTeamRepository_Call {
+	return &GitGetGithubI_AddTeamRepository_Call{Call: _e.mock.On("AddTeamRepository", ctx, repositorySha, baseURL, org, teamSlug, repository, permission)}
+}
+
+func (_c *GitGetGithubI_AddTeamRepository_Call) Run(run func(ctx context.Context, repositorySha string, baseURL string, org string, teamSlug string, repository string, permission string)) *GitGetGithubI_AddTeamRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		var arg6 string
+		if args[6] != nil {
+			arg6 = args[6].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGithubI_AddTeamRepository_Call) Return(err error) *GitGetGithubI_AddTeamRepository_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *GitGetGithubI_AddTeamRepository_Call) RunAndReturn(run func(ctx context.Context, repositorySha string, baseURL string, org string, teamSlug string, repository string, permission string) error) *GitGetGithubI_AddTeamRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateRepository provides a mock function for the type GitGetGithubI
-func (_mock *GitGetGithubI) CreateRepository(ctx context.Context, repositorySha string, repository string, mirrorVisibilityMode string, sourceURL string) *github.Repository {
-	ret := _mock.Called(ctx, repositorySha, repository, mirrorVisibilityMode, sourceURL)
+func (_mock *GitGetGithubI) CreateRepository(ctx context.Context, repositorySha string, baseURL string, owner string, repository string, mirrorVisibilityMode string, sourceURL string) *github.Repository {
+	ret := _mock.Called(ctx, repositorySha, baseURL, owner, repository, mirrorVisibilityMode, sourceURL)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateRepository")
 	}
 
 	var r0 *github.Repository
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) *github.Repository); ok {
-		r0 = returnFunc(ctx, repositorySha, repository, mirrorVisibilityMode, sourceURL)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, string) *github.Repository); ok {
+		r0 = returnFunc(ctx, repositorySha, baseURL, owner, repository, mirrorVisibilityMode, sourceURL)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*github.Repository)
@@ -65,14 +154,16 @@ type GitGetGithubI_CreateRepository_Call struct {
 // CreateRepository is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repositorySha string
+//   - baseURL string
+//   - owner string
 //   - repository string
 //   - mirrorVisibilityMode string
 //   - sourceURL string
-func (_e *GitGetGithubI_Expecter) CreateRepository(ctx interface{}, repositorySha interface{}, repository interface{}, mirrorVisibilityMode interface{}, sourceURL interface{}) *GitGetGithubI_CreateRepository_Call {
-	return &GitGetGithubI_CreateRepository_Call{Call: _e.mock.On("CreateRepository", ctx, repositorySha, repository, mirrorVisibilityMode, sourceURL)}
+func (_e *GitGetGithubI_Expecter) CreateRepository(ctx interface{}, repositorySha interface{}, baseURL interface{}, owner interface{}, repository interface{}, mirrorVisibilityMode interface{}, sourceURL interface{}) *GitGetGithubI_CreateRepository_Call {
+	return &GitGetGithubI_CreateRepository_Call{Call: _e.mock.On("CreateRepository", ctx, repositorySha, baseURL, owner, repository, mirrorVisibilityMode, sourceURL)}
 }
 
-func (_c *GitGetGithubI_CreateRepository_Call) Run(run func(ctx context.Context, repositorySha string, repository string, mirrorVisibilityMode string, sourceURL string)) *GitGetGithubI_CreateRepository_Call {
+func (_c *GitGetGithubI_CreateRepository_Call) Run(run func(ctx context.Context, repositorySha string, baseURL string, owner string, repository string, mirrorVisibilityMode string, sourceURL string)) *GitGetGithubI_CreateRepository_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -94,12 +185,22 @@ func (_c *GitGetGithubI_CreateRepository_Call) Run(run func(ctx context.Context,
 		if args[4] != nil {
 			arg4 = args[4].(string)
 		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		var arg6 string
+		if args[6] != nil {
+			arg6 = args[6].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
 			arg4,
+			arg5,
+			arg6,
 		)
 	})
 	return _c
@@ -110,22 +211,22 @@ func (_c *GitGetGithubI_CreateRepository_Call) Return(repository1 *github.Reposi
 	return _c
 }
 
-func (_c *GitGetGithubI_CreateRepository_Call) RunAndReturn(run func(ctx context.Context, repositorySha string, repository string, mirrorVisibilityMode string, sourceURL string) *github.Repository) *GitGetGithubI_CreateRepository_Call {
+func (_c *GitGetGithubI_CreateRepository_Call) RunAndReturn(run func(ctx context.Context, repositorySha string, baseURL string, owner string, repository string, mirrorVisibilityMode string, sourceURL string) *github.Repository) *GitGetGithubI_CreateRepository_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // FetchOwnerRepos provides a mock function for the type GitGetGithubI
-func (_mock *GitGetGithubI) FetchOwnerRepos(ctx context.Context, repoSha string, owner string, githubVisibility string, githubAffiliation string) []*github.Repository {
-	ret := _mock.Called(ctx, repoSha, owner, githubVisibility, githubAffiliation)
+func (_mock *GitGetGithubI) FetchOwnerRepos(ctx context.Context, repoSha string, baseURL string, owner string, githubVisibility string, githubAffiliation string) []*github.Repository {
+	ret := _mock.Called(ctx, repoSha, baseURL, owner, githubVisibility, githubAffiliation)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FetchOwnerRepos")
 	}
 
 	var r0 []*github.Repository
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) []*github.Repository); ok {
-		r0 = returnFunc(ctx, repoSha, owner, githubVisibility, githubAffiliation)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) []*github.Repository); ok {
+		r0 = returnFunc(ctx, repoSha, baseURL, owner, githubVisibility, githubAffiliation)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*github.Repository)
@@ -142,14 +243,15 @@ type GitGetGithubI_FetchOwnerRepos_Call struct {
 // FetchOwnerRepos is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repoSha string
+//   - baseURL string
 //   - owner string
 //   - githubVisibility string
 //   - githubAffiliation string
-func (_e *GitGetGithubI_Expecter) FetchOwnerRepos(ctx interface{}, repoSha interface{}, owner interface{}, githubVisibility interface{}, githubAffiliation interface{}) *GitGetGithubI_FetchOwnerRepos_Call {
-	return &GitGetGithubI_FetchOwnerRepos_Call{Call: _e.mock.On("FetchOwnerRepos", ctx, repoSha, owner, githubVisibility, githubAffiliation)}
+func (_e *GitGetGithubI_Expecter) FetchOwnerRepos(ctx interface{}, repoSha interface{}, baseURL interface{}, owner interface{}, githubVisibility interface{}, githubAffiliation interface{}) *GitGetGithubI_FetchOwnerRepos_Call {
+	return &GitGetGithubI_FetchOwnerRepos_Call{Call: _e.mock.On("FetchOwnerRepos", ctx, repoSha, baseURL, owner, githubVisibility, githubAffiliation)}
 }
 
-func (_c *GitGetGithubI_FetchOwnerRepos_Call) Run(run func(ctx context.Context, repoSha string, owner string, githubVisibility string, githubAffiliation string)) *GitGetGithubI_FetchOwnerRepos_Call {
+func (_c *GitGetGithubI_FetchOwnerRepos_Call) Run(run func(ctx context.Context, repoSha string, baseURL string, owner string, githubVisibility string, githubAffiliation string)) *GitGetGithubI_FetchOwnerRepos_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -171,12 +273,17 @@ func (_c *GitGetGithubI_FetchOwnerRepos_Call) Run(run func(ctx context.Context,
 		if args[4] != nil {
 			arg4 = args[4].(string)
 		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
 			arg4,
+			arg5,
 		)
 	})
 	return _c
@@ -187,7 +294,168 @@ func (_c *GitGetGithubI_FetchOwnerRepos_Call) Return(repositorys []*github.Repos
 	return _c
 }
 
-func (_c *GitGetGithubI_FetchOwnerRepos_Call) RunAndReturn(run func(ctx context.Context, repoSha string, owner string, githubVisibility string, githubAffiliation string) []*github.Repository) *GitGetGithubI_FetchOwnerRepos_Call {
+func (_c *GitGetGithubI_FetchOwnerRepos_Call) RunAndReturn(run func(ctx context.Context, repoSha string, baseURL string, owner string, githubVisibility string, githubAffiliation string) []*github.Repository) *GitGetGithubI_FetchOwnerRepos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchTeamRepos provides a mock function for the type GitGetGithubI
+func (_mock *GitGetGithubI) FetchTeamRepos(ctx context.Context, repoSha string, baseURL string, owner string, team string) []github1.TeamRepositories {
+	ret := _mock.Called(ctx, repoSha, baseURL, owner, team)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchTeamRepos")
+	}
+
+	var r0 []github1.TeamRepositories
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) []github1.TeamRepositories); ok {
+		r0 = returnFunc(ctx, repoSha, baseURL, owner, team)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]github1.TeamRepositories)
+		}
+	}
+	return r0
+}
+
+// GitGetGithubI_FetchTeamRepos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchTeamRepos'
+type GitGetGithubI_FetchTeamRepos_Call struct {
+	*mock.Call
+}
+
+// FetchTeamRepos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repoSha string
+//   - baseURL string
+//   - owner string
+//   - team string
+func (_e *GitGetGithubI_Expecter) FetchTeamRepos(ctx interface{}, repoSha interface{}, baseURL interface{}, owner interface{}, team interface{}) *GitGetGithubI_FetchTeamRepos_Call {
+	return &GitGetGithubI_FetchTeamRepos_Call{Call: _e.mock.On("FetchTeamRepos", ctx, repoSha, baseURL, owner, team)}
+}
+
+func (_c *GitGetGithubI_FetchTeamRepos_Call) Run(run func(ctx context.Context, repoSha string, baseURL string, owner string, team string)) *GitGetGithubI_FetchTeamRepos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGithubI_FetchTeamRepos_Call) Return(teamRepositoriess []github1.TeamRepositories) *GitGetGithubI_FetchTeamRepos_Call {
+	_c.Call.Return(teamRepositoriess)
+	return _c
+}
+
+func (_c *GitGetGithubI_FetchTeamRepos_Call) RunAndReturn(run func(ctx context.Context, repoSha string, baseURL string, owner string, team string) []github1.TeamRepositories) *GitGetGithubI_FetchTeamRepos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepositoryMetadata provides a mock function for the type GitGetGithubI
+func (_mock *GitGetGithubI) GetRepositoryMetadata(ctx context.Context, repositorySha string, baseURL string, owner string, repository string) (metadata.Repository, error) {
+	ret := _mock.Called(ctx, repositorySha, baseURL, owner, repository)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepositoryMetadata")
+	}
+
+	var r0 metadata.Repository
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (metadata.Repository, error)); ok {
+		return returnFunc(ctx, repositorySha, baseURL, owner, repository)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) metadata.Repository); ok {
+		r0 = returnFunc(ctx, repositorySha, baseURL, owner, repository)
+	} else {
+		r0 = ret.Get(0).(metadata.Repository)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, repositorySha, baseURL, owner, repository)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// GitGetGithubI_GetRepositoryMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepositoryMetadata'
+type GitGetGithubI_GetRepositoryMetadata_Call struct {
+	*mock.Call
+}
+
+// GetRepositoryMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositorySha string
+//   - baseURL string
+//   - owner string
+//   - repository string
+func (_e *GitGetGithubI_Expecter) GetRepositoryMetadata(ctx interface{}, repositorySha interface{}, baseURL interface{}, owner interface{}, repository interface{}) *GitGetGithubI_GetRepositoryMetadata_Call {
+	return &GitGetGithubI_GetRepositoryMetadata_Call{Call: _e.mock.On("GetRepositoryMetadata", ctx, repositorySha, baseURL, owner, repository)}
+}
+
+func (_c *GitGetGithubI_GetRepositoryMetadata_Call) Run(run func(ctx context.Context, repositorySha string, baseURL string, owner string, repository string)) *GitGetGithubI_GetRepositoryMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGithubI_GetRepositoryMetadata_Call) Return(repository1 metadata.Repository, err error) *GitGetGithubI_GetRepositoryMetadata_Call {
+	_c.Call.Return(repository1, err)
+	return _c
+}
+
+func (_c *GitGetGithubI_GetRepositoryMetadata_Call) RunAndReturn(run func(ctx context.Context, repositorySha string, baseURL string, owner string, repository string) (metadata.Repository, error)) *GitGetGithubI_GetRepositoryMetadata_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -236,17 +504,119 @@ func (_c *GitGetGithubI_Init_Call) RunAndReturn(run func() bool) *GitGetGithubI_
 	return _c
 }
 
+// InitForHost provides a mock function for the type GitGetGithubI
+func (_mock *GitGetGithubI) InitForHost(host string) bool {
+	ret := _mock.Called(host)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitForHost")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(host)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// GitGetGithubI_InitForHost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InitForHost'
+type GitGetGithubI_InitForHost_Call struct {
+	*mock.Call
+}
+
+// InitForHost is a helper method to define mock.On call
+//   - host string
+func (_e *GitGetGithubI_Expecter) InitForHost(host interface{}) *GitGetGithubI_InitForHost_Call {
+	return &GitGetGithubI_InitForHost_Call{Call: _e.mock.On("InitForHost", host)}
+}
+
+func (_c *GitGetGithubI_InitForHost_Call) Run(run func(host string)) *GitGetGithubI_InitForHost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGithubI_InitForHost_Call) Return(b bool) *GitGetGithubI_InitForHost_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *GitGetGithubI_InitForHost_Call) RunAndReturn(run func(host string) bool) *GitGetGithubI_InitForHost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InitTokenForHost provides a mock function for the type GitGetGithubI
+func (_mock *GitGetGithubI) InitTokenForHost(host string) bool {
+	ret := _mock.Called(host)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitTokenForHost")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(host)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// GitGetGithubI_InitTokenForHost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InitTokenForHost'
+type GitGetGithubI_InitTokenForHost_Call struct {
+	*mock.Call
+}
+
+// InitTokenForHost is a helper method to define mock.On call
+//   - host string
+func (_e *GitGetGithubI_Expecter) InitTokenForHost(host interface{}) *GitGetGithubI_InitTokenForHost_Call {
+	return &GitGetGithubI_InitTokenForHost_Call{Call: _e.mock.On("InitTokenForHost", host)}
+}
+
+func (_c *GitGetGithubI_InitTokenForHost_Call) Run(run func(host string)) *GitGetGithubI_InitTokenForHost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGithubI_InitTokenForHost_Call) Return(b bool) *GitGetGithubI_InitTokenForHost_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *GitGetGithubI_InitTokenForHost_Call) RunAndReturn(run func(host string) bool) *GitGetGithubI_InitTokenForHost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RepositoryExists provides a mock function for the type GitGetGithubI
-func (_mock *GitGetGithubI) RepositoryExists(ctx context.Context, repositorySha string, owner string, repository string) bool {
-	ret := _mock.Called(ctx, repositorySha, owner, repository)
+func (_mock *GitGetGithubI) RepositoryExists(ctx context.Context, repositorySha string, baseURL string, owner string, repository string) bool {
+	ret := _mock.Called(ctx, repositorySha, baseURL, owner, repository)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RepositoryExists")
 	}
 
 	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
-		r0 = returnFunc(ctx, repositorySha, owner, repository)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) bool); ok {
+		r0 = returnFunc(ctx, repositorySha, baseURL, owner, repository)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -261,13 +631,14 @@ type GitGetGithubI_RepositoryExists_Call struct {
 // RepositoryExists is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repositorySha string
+//   - baseURL string
 //   - owner string
 //   - repository string
-func (_e *GitGetGithubI_Expecter) RepositoryExists(ctx interface{}, repositorySha interface{}, owner interface{}, repository interface{}) *GitGetGithubI_RepositoryExists_Call {
-	return &GitGetGithubI_RepositoryExists_Call{Call: _e.mock.On("RepositoryExists", ctx, repositorySha, owner, repository)}
+func (_e *GitGetGithubI_Expecter) RepositoryExists(ctx interface{}, repositorySha interface{}, baseURL interface{}, owner interface{}, repository interface{}) *GitGetGithubI_RepositoryExists_Call {
+	return &GitGetGithubI_RepositoryExists_Call{Call: _e.mock.On("RepositoryExists", ctx, repositorySha, baseURL, owner, repository)}
 }
 
-func (_c *GitGetGithubI_RepositoryExists_Call) Run(run func(ctx context.Context, repositorySha string, owner string, repository string)) *GitGetGithubI_RepositoryExists_Call {
+func (_c *GitGetGithubI_RepositoryExists_Call) Run(run func(ctx context.Context, repositorySha string, baseURL string, owner string, repository string)) *GitGetGithubI_RepositoryExists_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -285,11 +656,16 @@ func (_c *GitGetGithubI_RepositoryExists_Call) Run(run func(ctx context.Context,
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -300,7 +676,360 @@ func (_c *GitGetGithubI_RepositoryExists_Call) Return(b bool) *GitGetGithubI_Rep
 	return _c
 }
 
-func (_c *GitGetGithubI_RepositoryExists_Call) RunAndReturn(run func(ctx context.Context, repositorySha string, owner string, repository string) bool) *GitGetGithubI_RepositoryExists_Call {
+func (_c *GitGetGithubI_RepositoryExists_Call) RunAndReturn(run func(ctx context.Context, repositorySha string, baseURL string, owner string, repository string) bool) *GitGetGithubI_RepositoryExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCABundle provides a mock function for the type GitGetGithubI
+func (_mock *GitGetGithubI) SetCABundle(caBundle string) {
+	_mock.Called(caBundle)
+	return
+}
+
+// GitGetGithubI_SetCABundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCABundle'
+type GitGetGithubI_SetCABundle_Call struct {
+	*mock.Call
+}
+
+// SetCABundle is a helper method to define mock.On call
+//   - caBundle string
+func (_e *GitGetGithubI_Expecter) SetCABundle(caBundle interface{}) *GitGetGithubI_SetCABundle_Call {
+	return &GitGetGithubI_SetCABundle_Call{Call: _e.mock.On("SetCABundle", caBundle)}
+}
+
+func (_c *GitGetGithubI_SetCABundle_Call) Run(run func(caBundle string)) *GitGetGithubI_SetCABundle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGithubI_SetCABundle_Call) Return() *GitGetGithubI_SetCABundle_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *GitGetGithubI_SetCABundle_Call) RunAndReturn(run func(caBundle string)) *GitGetGithubI_SetCABundle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TeamHasRepository provides a mock function for the type GitGetGithubI
+func (_mock *GitGetGithubI) TeamHasRepository(ctx context.Context, repositorySha string, baseURL string, org string, teamSlug string, repository string) (bool, error) {
+	ret := _mock.Called(ctx, repositorySha, baseURL, org, teamSlug, repository)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TeamHasRepository")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) (bool, error)); ok {
+		return returnFunc(ctx, repositorySha, baseURL, org, teamSlug, repository)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) bool); ok {
+		r0 = returnFunc(ctx, repositorySha, baseURL, org, teamSlug, repository)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, repositorySha, baseURL, org, teamSlug, repository)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// GitGetGithubI_TeamHasRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TeamHasRepository'
+type GitGetGithubI_TeamHasRepository_Call struct {
+	*mock.Call
+}
+
+// TeamHasRepository is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositorySha string
+//   - baseURL string
+//   - org string
+//   - teamSlug string
+//   - repository string
+func (_e *GitGetGithubI_Expecter) TeamHasRepository(ctx interface{}, repositorySha interface{}, baseURL interface{}, org interface{}, teamSlug interface{}, repository interface{}) *GitGetGithubI_TeamHasRepository_Call {
+	return &GitGetGithubI_TeamHasRepository_Call{Call: _e.mock.On("TeamHasRepository", ctx, repositorySha, baseURL, org, teamSlug, repository)}
+}
+
+func (_c *GitGetGithubI_TeamHasRepository_Call) Run(run func(ctx context.Context, repositorySha string, baseURL string, org string, teamSlug string, repository string)) *GitGetGithubI_TeamHasRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGithubI_TeamHasRepository_Call) Return(b bool, err error) *GitGetGithubI_TeamHasRepository_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *GitGetGithubI_TeamHasRepository_Call) RunAndReturn(run func(ctx context.Context, repositorySha string, baseURL string, org string, teamSlug string, repository string) (bool, error)) *GitGetGithubI_TeamHasRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Token provides a mock function for the type GitGetGithubI
+func (_mock *GitGetGithubI) Token(ctx context.Context, repositorySha string, baseURL string) (string, error) {
+	ret := _mock.Called(ctx, repositorySha, baseURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Token")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, repositorySha, baseURL)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, repositorySha, baseURL)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repositorySha, baseURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// GitGetGithubI_Token_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Token'
+type GitGetGithubI_Token_Call struct {
+	*mock.Call
+}
+
+// Token is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositorySha string
+//   - baseURL string
+func (_e *GitGetGithubI_Expecter) Token(ctx interface{}, repositorySha interface{}, baseURL interface{}) *GitGetGithubI_Token_Call {
+	return &GitGetGithubI_Token_Call{Call: _e.mock.On("Token", ctx, repositorySha, baseURL)}
+}
+
+func (_c *GitGetGithubI_Token_Call) Run(run func(ctx context.Context, repositorySha string, baseURL string)) *GitGetGithubI_Token_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGithubI_Token_Call) Return(s string, err error) *GitGetGithubI_Token_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *GitGetGithubI_Token_Call) RunAndReturn(run func(ctx context.Context, repositorySha string, baseURL string) (string, error)) *GitGetGithubI_Token_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRepositoryMetadata provides a mock function for the type GitGetGithubI
+func (_mock *GitGetGithubI) UpdateRepositoryMetadata(ctx context.Context, repositorySha string, baseURL string, owner string, repository string, current metadata.Repository, desired metadata.Repository) error {
+	ret := _mock.Called(ctx, repositorySha, baseURL, owner, repository, current, desired)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRepositoryMetadata")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, metadata.Repository, metadata.Repository) error); ok {
+		r0 = returnFunc(ctx, repositorySha, baseURL, owner, repository, current, desired)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// GitGetGithubI_UpdateRepositoryMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRepositoryMetadata'
+type GitGetGithubI_UpdateRepositoryMetadata_Call struct {
+	*mock.Call
+}
+
+// UpdateRepositoryMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositorySha string
+//   - baseURL string
+//   - owner string
+//   - repository string
+//   - current metadata.Repository
+//   - desired metadata.Repository
+func (_e *GitGetGithubI_Expecter) UpdateRepositoryMetadata(ctx interface{}, repositorySha interface{}, baseURL interface{}, owner interface{}, repository interface{}, current interface{}, desired interface{}) *GitGetGithubI_UpdateRepositoryMetadata_Call {
+	return &GitGetGithubI_UpdateRepositoryMetadata_Call{Call: _e.mock.On("UpdateRepositoryMetadata", ctx, repositorySha, baseURL, owner, repository, current, desired)}
+}
+
+func (_c *GitGetGithubI_UpdateRepositoryMetadata_Call) Run(run func(ctx context.Context, repositorySha string, baseURL string, owner string, repository string, current metadata.Repository, desired metadata.Repository)) *GitGetGithubI_UpdateRepositoryMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 metadata.Repository
+		if args[5] != nil {
+			arg5 = args[5].(metadata.Repository)
+		}
+		var arg6 metadata.Repository
+		if args[6] != nil {
+			arg6 = args[6].(metadata.Repository)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGithubI_UpdateRepositoryMetadata_Call) Return(err error) *GitGetGithubI_UpdateRepositoryMetadata_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *GitGetGithubI_UpdateRepositoryMetadata_Call) RunAndReturn(run func(ctx context.Context, repositorySha string, baseURL string, owner string, repository string, current metadata.Repository, desired metadata.Repository) error) *GitGetGithubI_UpdateRepositoryMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateTeam provides a mock function for the type GitGetGithubI
+func (_mock *GitGetGithubI) ValidateTeam(ctx context.Context, repositorySha string, baseURL string, org string, teamSlug string) {
+	_mock.Called(ctx, repositorySha, baseURL, org, teamSlug)
+	return
+}
+
+// GitGetGithubI_ValidateTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateTeam'
+type GitGetGithubI_ValidateTeam_Call struct {
+	*mock.Call
+}
+
+// ValidateTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositorySha string
+//   - baseURL string
+//   - org string
+//   - teamSlug string
+func (_e *GitGetGithubI_Expecter) ValidateTeam(ctx interface{}, repositorySha interface{}, baseURL interface{}, org interface{}, teamSlug interface{}) *GitGetGithubI_ValidateTeam_Call {
+	return &GitGetGithubI_ValidateTeam_Call{Call: _e.mock.On("ValidateTeam", ctx, repositorySha, baseURL, org, teamSlug)}
+}
+
+func (_c *GitGetGithubI_ValidateTeam_Call) Run(run func(ctx context.Context, repositorySha string, baseURL string, org string, teamSlug string)) *GitGetGithubI_ValidateTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGithubI_ValidateTeam_Call) Return() *GitGetGithubI_ValidateTeam_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *GitGetGithubI_ValidateTeam_Call) RunAndReturn(run func(ctx context.Context, repositorySha string, baseURL string, org string, teamSlug string)) *GitGetGithubI_ValidateTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -24,22 +24,44 @@ package gitlab
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/isindir/git-get/credentials"
+	"github.com/isindir/git-get/metadata"
+	"github.com/isindir/git-get/transport"
+)
+
+// publicHost - default Gitlab host
+const publicHost = "gitlab.com"
+
+var (
+	// characters not allowed in Gitlab project and group paths
+	pathInvalidRe = regexp.MustCompile(`[^a-z0-9_.-]+`)
+	// repeated separators are not allowed in Gitlab paths
+	pathSeparatorsRe = regexp.MustCompile(`[-_.]*-[-_.]*`)
 )
 
 type GitGetGitlab struct {
-	token  string
-	client *gitlab.Client
+	token string
+	// API clients are created once per host and shared by concurrent calls
+	mutex   sync.Mutex
+	clients map[string]*gitlab.Client
+	// serializes group creation, so that concurrent mirrors do not create the same group twice
+	groupMutex sync.Mutex
 }
 
 type GitGetGitlabI interface {
 	Init() bool
+	InitForHost(host string) bool
 
 	gitlabAuth(
 		repositorySha string,
@@ -61,14 +83,45 @@ type GitGetGitlabI interface {
 		groupName string,
 	) (int64, string, error)
 
+	GetNamespaceFullPath(
+		repositorySha string,
+		baseUrl string,
+		namespaceID int64,
+	) string
 	CreateProject(
 		repositorySha string,
 		baseUrl string,
 		projectName string,
+		projectPath string,
 		namespaceID int64,
 		mirrorVisibilityMode string,
 		sourceURL string,
 	) *gitlab.Project
+	EnsureGroupPath(
+		repositorySha string,
+		baseUrl string,
+		groupFullPath string,
+		mirrorVisibilityMode string,
+	) *gitlab.Group
+	GetProjectMetadata(
+		repositorySha string,
+		baseUrl string,
+		projectNameFullPath string,
+	) (metadata.Repository, error)
+	UpdateProjectMetadata(
+		repositorySha string,
+		baseUrl string,
+		projectNameFullPath string,
+		current, desired metadata.Repository,
+	) error
+	ConfigurePullMirror(
+		repositorySha string,
+		baseUrl string,
+		projectNameFullPath string,
+		sourceURL string,
+		authUser string,
+		authPassword string,
+	)
 
 	processSubgroups(
 		repoSha string,
@@ -97,35 +150,56 @@ type GitGetGitlabI interface {
 	) []*gitlab.Project
 }
 
+// Init - reads credentials for gitlab.com, see InitForHost
 func (gitProvider *GitGetGitlab) Init() bool {
-	var tokenFound bool
-	gitProvider.token, tokenFound = os.LookupEnv("GITLAB_TOKEN")
-	if !tokenFound {
-		log.Fatal("Error - environment variable GITLAB_TOKEN not found")
+	return gitProvider.InitForHost(publicHost)
+}
+
+// InitForHost - reads token of the host from credentials hosts file or GITLAB_TOKEN
+func (gitProvider *GitGetGitlab) InitForHost(host string) bool {
+	hostCredentials, err := credentials.Lookup(host, "", "GITLAB_TOKEN")
+	if err != nil {
+		log.Fatalf("Error - Gitlab credentials for '%s': %s", host, err)
 		os.Exit(1)
 	}
+	gitProvider.token = hostCredentials.Token
 
-	return tokenFound
+	return true
 }
 
-func (gitProvider *GitGetGitlab) auth(repositorySha, baseUrl string) bool {
-	clientOptions := gitlab.WithBaseURL("https://" + baseUrl)
-	var err error
-	gitProvider.client, err = gitlab.NewClient(gitProvider.token, clientOptions)
+// auth - returns API client for the host, client is created on first use and reused afterwards
+func (gitProvider *GitGetGitlab) auth(repositorySha, baseUrl string) *gitlab.Client {
+	gitProvider.mutex.Lock()
+	defer gitProvider.mutex.Unlock()
+
+	if git, found := gitProvider.clients[baseUrl]; found {
+		return git
+	}
+
+	git, err := gitlab.NewClient(
+		gitProvider.token,
+		gitlab.WithBaseURL("https://"+baseUrl),
+		gitlab.WithHTTPClient(transport.Client()),
+	)
 	if err != nil {
 		log.Fatalf("%s: Error - while trying to authenticate to Gitlab: %s", repositorySha, err)
 		os.Exit(1)
 	}
 
-	return true
+	if gitProvider.clients == nil {
+		gitProvider.clients = map[string]*gitlab.Client{}
+	}
+	gitProvider.clients[baseUrl] = git
+
+	return git
 }
 
 // ProjectExists checks if project exists and returns boolean if API call is successful
 func (gitProvider *GitGetGitlab) ProjectExists(repositorySha, baseUrl, projectName string) bool {
 	log.Debugf("%s: Checking repository '%s' '%s' existence", repositorySha, baseUrl, projectName)
-	gitProvider.auth(repositorySha, baseUrl)
+	git := gitProvider.auth(repositorySha, baseUrl)
 
-	prj, _, err := gitProvider.client.Projects.GetProject(projectName, nil, nil)
+	prj, _, err := git.Projects.GetProject(projectName, nil, nil)
 
 	log.Debugf("%s: project: '%+v'", repositorySha, prj)
 
@@ -139,7 +213,7 @@ func (gitProvider *GitGetGitlab) GetProjectNamespace(
 	projectNameFullPath string,
 ) (namespaceObject *gitlab.Namespace, namespaceFullPath string) {
 	log.Debugf("%s: Getting Project FullPath Namespace '%s'", repositorySha, projectNameFullPath)
-	gitProvider.auth(repositorySha, baseUrl)
+	git := gitProvider.auth(repositorySha, baseUrl)
 
 	pathElements := strings.Split(projectNameFullPath, "/")
 	// Remove short project name from the path elements list
@@ -148,7 +222,7 @@ func (gitProvider *GitGetGitlab) GetProjectNamespace(
 	}
 	namespaceFullPath = strings.Join(pathElements, "/")
 
-	namespaceObject, _, err := gitProvider.client.Namespaces.GetNamespace(namespaceFullPath, nil, nil)
+	namespaceObject, _, err := git.Namespaces.GetNamespace(namespaceFullPath, nil, nil)
 
 	log.Debugf(
 		"%s: Getting namespace '%s': resulting namespace object: '%+v'",
@@ -161,19 +235,47 @@ func (gitProvider *GitGetGitlab) GetProjectNamespace(
 	return namespaceObject, namespaceFullPath
 }
 
-// CreateProject - Create new code repository
+// GetNamespaceFullPath - returns full path of group or user namespace identified by ID
+func (gitProvider *GitGetGitlab) GetNamespaceFullPath(repositorySha, baseUrl string, namespaceID int64) string {
+	git := gitProvider.auth(repositorySha, baseUrl)
+
+	namespace, _, err := git.Namespaces.GetNamespace(namespaceID, nil)
+	if err != nil {
+		log.Fatalf("%s: Error - while trying to get gitlab namespace '%d': '%s'", repositorySha, namespaceID, err)
+		os.Exit(1)
+	}
+
+	return namespace.FullPath
+}
+
+// PathSlug - returns Gitlab project or group path for the name, Gitlab generates lowercase paths
+// from names containing spaces or other characters not allowed in paths ( "My Repo" -> "my-repo" )
+func PathSlug(name string) string {
+	slug := pathInvalidRe.ReplaceAllString(strings.ToLower(name), "-")
+	slug = pathSeparatorsRe.ReplaceAllString(slug, "-")
+	slug = strings.Trim(slug, "-_.")
+	slug = strings.TrimSuffix(strings.TrimSuffix(slug, ".git"), ".atom")
+
+	return slug
+}
+
+// CreateProject - Create new code repository, project path can differ from its name, namespace ID
+// of a group or user namespace is required to create project outside of token owner namespace,
+// creating project in namespace of another user requires administrator token
 func (gitProvider *GitGetGitlab) CreateProject(
 	repositorySha string,
 	baseUrl string,
 	projectName string,
+	projectPath string,
 	namespaceID int64,
 	mirrorVisibilityMode string,
 	sourceURL string,
 ) *gitlab.Project {
-	gitProvider.auth(repositorySha, baseUrl)
+	git := gitProvider.auth(repositorySha, baseUrl)
 
 	p := &gitlab.CreateProjectOptions{
 		Name: gitlab.Ptr(projectName),
+		Path: gitlab.Ptr(projectPath),
 		Description: gitlab.Ptr(
 			fmt.Sprintf("Mirror of the '%s'", sourceURL),
 		),
@@ -186,7 +288,7 @@ func (gitProvider *GitGetGitlab) CreateProject(
 		p.NamespaceID = gitlab.Ptr(namespaceID)
 	}
 
-	project, _, err := gitProvider.client.Projects.CreateProject(p)
+	project, _, err := git.Projects.CreateProject(p)
 	if err != nil {
 		log.Fatalf(
 			"%s: Error - while trying to create gitlab project '%s': '%s'",
@@ -200,6 +302,197 @@ func (gitProvider *GitGetGitlab) CreateProject(
 	return project
 }
 
+// EnsureGroupPath - returns group specified by full path (e.g. 'mirrors/a/b'), creating all
+// missing groups and subgroups of the path with specified visibility
+func (gitProvider *GitGetGitlab) EnsureGroupPath(
+	repositorySha string,
+	baseUrl string,
+	groupFullPath string,
+	mirrorVisibilityMode string,
+) *gitlab.Group {
+	git := gitProvider.auth(repositorySha, baseUrl)
+
+	gitProvider.groupMutex.Lock()
+	defer gitProvider.groupMutex.Unlock()
+
+	var parent *gitlab.Group
+	pathElements := strings.Split(strings.Trim(groupFullPath, "/"), "/")
+	for element := 0; element < len(pathElements); element++ {
+		currentPath := strings.Join(pathElements[:element+1], "/")
+
+		group, res, err := git.Groups.GetGroup(currentPath, nil)
+		if err == nil {
+			log.Debugf("%s: Group '%s' exists with ID '%d'", repositorySha, currentPath, group.ID)
+			parent = group
+			continue
+		}
+		if res == nil || res.StatusCode != http.StatusNotFound {
+			log.Fatalf(
+				"%s: Error - while trying to get gitlab group '%s': '%s'",
+				repositorySha, currentPath, err)
+			os.Exit(1)
+		}
+
+		log.Infof("%s: Creating gitlab group '%s' on '%s'", repositorySha, currentPath, baseUrl)
+		groupOptions := &gitlab.CreateGroupOptions{
+			Name:       gitlab.Ptr(pathElements[element]),
+			Path:       gitlab.Ptr(pathElements[element]),
+			Visibility: gitlab.Ptr(gitlab.VisibilityValue(mirrorVisibilityMode)),
+		}
+		if parent != nil {
+			groupOptions.ParentID = gitlab.Ptr(parent.ID)
+		}
+
+		group, _, err = git.Groups.CreateGroup(groupOptions)
+		if err != nil {
+			log.Fatalf(
+				"%s: Error - while trying to create gitlab group '%s': '%s'",
+				repositorySha, currentPath, err)
+			os.Exit(1)
+		}
+		parent = group
+	}
+
+	return parent
+}
+
+// GetProjectMetadata - returns metadata of gitlab project, Gitlab projects have no homepage
+func (gitProvider *GitGetGitlab) GetProjectMetadata(
+	repositorySha string,
+	baseUrl string,
+	projectNameFullPath string,
+) (metadata.Repository, error) {
+	git := gitProvider.auth(repositorySha, baseUrl)
+	project, _, err := git.Projects.GetProject(projectNameFullPath, nil)
+	if err != nil {
+		return metadata.Repository{}, err
+	}
+
+	return metadata.Repository{
+		Description:   project.Description,
+		Topics:        project.Topics,
+		DefaultBranch: project.DefaultBranch,
+		Visibility:    string(project.Visibility),
+		Archived:      project.Archived,
+	}, nil
+}
+
+// UpdateProjectMetadata - updates gitlab project fields which differ from desired metadata, archived
+// project is read-only, so it is unarchived before and archived after other changes
+func (gitProvider *GitGetGitlab) UpdateProjectMetadata(
+	repositorySha string,
+	baseUrl string,
+	projectNameFullPath string,
+	current, desired metadata.Repository,
+) (err error) {
+	if current.Archived && desired.Archived {
+		log.Debugf("%s: gitlab project '%s' is archived, skipping metadata update", repositorySha, projectNameFullPath)
+		return nil
+	}
+
+	git := gitProvider.auth(repositorySha, baseUrl)
+	if current.Archived {
+		// archived project can't be edited, it is archived again if update fails
+		log.Debugf("%s: Unarchiving gitlab project '%s'", repositorySha, projectNameFullPath)
+		if _, _, err := git.Projects.UnarchiveProject(projectNameFullPath); err != nil {
+			return err
+		}
+		defer func() {
+			if err == nil {
+				return
+			}
+			log.Debugf("%s: Archiving gitlab project '%s' again after failed update", repositorySha, projectNameFullPath)
+			if _, _, archiveErr := git.Projects.ArchiveProject(projectNameFullPath); archiveErr != nil {
+				log.Warnf("%s: Unable to archive gitlab project '%s' again: %s", repositorySha, projectNameFullPath, archiveErr)
+			}
+		}()
+	}
+
+	edit := &gitlab.EditProjectOptions{}
+	changed := false
+	if current.Description != desired.Description {
+		edit.Description = gitlab.Ptr(desired.Description)
+		changed = true
+	}
+	if !metadata.SameTopics(current.Topics, desired.Topics) {
+		topics := desired.Topics
+		if topics == nil {
+			topics = []string{}
+		}
+		edit.Topics = &topics
+		changed = true
+	}
+	if desired.DefaultBranch != "" && current.DefaultBranch != desired.DefaultBranch {
+		edit.DefaultBranch = gitlab.Ptr(desired.DefaultBranch)
+		changed = true
+	}
+	if desired.Visibility != "" && current.Visibility != desired.Visibility {
+		edit.Visibility = gitlab.Ptr(gitlab.VisibilityValue(desired.Visibility))
+		changed = true
+	}
+
+	if changed {
+		log.Debugf("%s: Updating gitlab project '%s' metadata", repositorySha, projectNameFullPath)
+		if _, _, err := git.Projects.EditProject(projectNameFullPath, edit); err != nil {
+			return err
+		}
+	}
+
+	if desired.Archived {
+		log.Debugf("%s: Archiving gitlab project '%s'", repositorySha, projectNameFullPath)
+		if _, _, err := git.Projects.ArchiveProject(projectNameFullPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ConfigurePullMirror - configures project to pull all changes of the source repository on Gitlab side
+// and starts mirroring, fails when Gitlab doesn't provide pull mirroring API
+func (gitProvider *GitGetGitlab) ConfigurePullMirror(
+	repositorySha string,
+	baseUrl string,
+	projectNameFullPath string,
+	sourceURL string,
+	authUser string,
+	authPassword string,
+) {
+	git := gitProvider.auth(repositorySha, baseUrl)
+	log.Infof("%s: Configuring gitlab project '%s' to pull mirror '%s'", repositorySha, projectNameFullPath, sourceURL)
+
+	mirrorOptions := &gitlab.ConfigureProjectPullMirrorOptions{
+		Enabled:                          gitlab.Ptr(true),
+		URL:                              gitlab.Ptr(sourceURL),
+		MirrorOverwritesDivergedBranches: gitlab.Ptr(true),
+	}
+	if authPassword != "" {
+		mirrorOptions.AuthUser = gitlab.Ptr(authUser)
+		mirrorOptions.AuthPassword = gitlab.Ptr(authPassword)
+	}
+
+	_, res, err := git.Projects.ConfigureProjectPullMirror(projectNameFullPath, mirrorOptions)
+	if err != nil && res != nil && res.StatusCode == http.StatusNotFound {
+		log.Fatalf(
+			"%s: Error - pull mirroring API unavailable for gitlab project '%s', "+
+				"Gitlab version or license doesn't support pull mirroring: '%s'",
+			repositorySha, projectNameFullPath, err)
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatalf(
+			"%s: Error - while trying to configure pull mirror of gitlab project '%s': '%s'",
+			repositorySha, projectNameFullPath, err)
+		os.Exit(1)
+	}
+
+	_, err = git.Projects.StartMirroringProject(projectNameFullPath)
+	if err != nil {
+		log.Warnf("%s: Unable to start mirroring of gitlab project '%s', it will be updated on schedule: %s",
+			repositorySha, projectNameFullPath, err)
+	}
+}
+
 func (gitProvider *GitGetGitlab) getGroupID(
 	repoSha string,
 	git *gitlab.Client,
@@ -425,11 +718,11 @@ func (gitProvider *GitGetGitlab) FetchOwnerRepos(
 	gitlabOwned bool,
 	gitlabVisibility, gitlabMinAccessLevel string,
 ) []*gitlab.Project {
-	gitProvider.auth(repositorySha, baseURL)
+	git := gitProvider.auth(repositorySha, baseURL)
 	var glRepoList []*gitlab.Project
 
 	log.Debugf("%s: Get groupID for '%s'", repositorySha, groupName)
-	groupID, fullGroupName, err := gitProvider.getGroupID(repositorySha, gitProvider.client, groupName)
+	groupID, fullGroupName, err := gitProvider.getGroupID(repositorySha, git, groupName)
 	if err != nil {
 		log.Errorf(
 			"%s: Error while trying to find group '%s': %s",
@@ -441,7 +734,7 @@ func (gitProvider *GitGetGitlab) FetchOwnerRepos(
 
 	glRepoList = gitProvider.getRepositories(
 		repositorySha,
-		gitProvider.client,
+		git,
 		groupID,
 		fullGroupName,
 		glRepoList,
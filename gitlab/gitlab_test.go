@@ -4,10 +4,18 @@
 package gitlab
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/isindir/git-get/metadata"
 )
 
 func TestGitGetGitlab_Init_Success(t *testing.T) {
@@ -36,8 +44,154 @@ func TestGitGetGitlab_Init_MissingToken(t *testing.T) {
 }
 
 func TestGitGetGitlab_Auth(t *testing.T) {
-	// This test requires actual GitLab API or mocking at HTTP level
-	t.Skip("Requires GitLab API mocking or integration test")
+	gitProvider := &GitGetGitlab{token: "test-gitlab-token-123"}
+
+	client := gitProvider.auth("test-sha", "gitlab.acme.com")
+	assert.Equal(t, "https://gitlab.acme.com/api/v4/", client.BaseURL().String())
+
+	// client is created once per host and reused
+	assert.Same(t, client, gitProvider.auth("test-sha", "gitlab.acme.com"))
+	assert.NotSame(t, client, gitProvider.auth("test-sha", "gitlab.com"))
+}
+
+func TestGitGetGitlab_EnsureGroupPath(t *testing.T) {
+	existing := map[string]int64{"acme": 1}
+	var created []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			groupPath := r.URL.Path[len("/api/v4/groups/"):]
+			id, found := existing[groupPath]
+			if !found {
+				http.Error(w, `{"message":"404 Group Not Found"}`, http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": id, "full_path": groupPath})
+		case http.MethodPost:
+			var options map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+			created = append(created, options)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":        int64(len(existing) + len(created)),
+				"full_path": options["path"],
+			})
+		}
+	}))
+	defer server.Close()
+
+	client, err := gitlab.NewClient("test-token", gitlab.WithBaseURL(server.URL))
+	require.NoError(t, err)
+	gitProvider := &GitGetGitlab{clients: map[string]*gitlab.Client{"gitlab.acme.com": client}}
+
+	group := gitProvider.EnsureGroupPath("test-sha", "gitlab.acme.com", "acme/mirrors/team", "private")
+
+	assert.Equal(t, int64(3), group.ID)
+	assert.Len(t, created, 2)
+	assert.Equal(t, "mirrors", created[0]["path"])
+	assert.Equal(t, float64(1), created[0]["parent_id"])
+	assert.Equal(t, "private", created[0]["visibility"])
+	assert.Equal(t, "team", created[1]["path"])
+	assert.Equal(t, float64(2), created[1]["parent_id"])
+}
+
+func TestGitGetGitlab_ConfigurePullMirror(t *testing.T) {
+	var requests []string
+	var options map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		if r.Method == http.MethodPut {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := gitlab.NewClient("test-token", gitlab.WithBaseURL(server.URL))
+	require.NoError(t, err)
+	gitProvider := &GitGetGitlab{clients: map[string]*gitlab.Client{"gitlab.acme.com": client}}
+
+	gitProvider.ConfigurePullMirror(
+		"test-sha", "gitlab.acme.com", "acme/mirrors/api",
+		"https://github.com/acme/api.git", "x-access-token", "secret")
+
+	assert.Equal(t, []string{
+		"PUT /api/v4/projects/acme%2Fmirrors%2Fapi/mirror/pull",
+		"POST /api/v4/projects/acme%2Fmirrors%2Fapi/mirror/pull",
+	}, requests)
+	assert.Equal(t, "https://github.com/acme/api.git", options["url"])
+	assert.Equal(t, "x-access-token", options["auth_user"])
+	assert.Equal(t, "secret", options["auth_password"])
+	assert.Equal(t, true, options["enabled"])
+}
+
+func TestGitGetGitlab_ProjectMetadata(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"description":"API","topics":["go"],"default_branch":"main",` +
+				`"visibility":"internal","archived":false}`))
+			return
+		}
+		request := r.Method + " " + r.URL.EscapedPath()
+		if r.Method == http.MethodPut {
+			var options map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+			request += " " + fmt.Sprint(options)
+		}
+		requests = append(requests, request)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := gitlab.NewClient("test-token", gitlab.WithBaseURL(server.URL))
+	require.NoError(t, err)
+	gitProvider := &GitGetGitlab{clients: map[string]*gitlab.Client{"gitlab.acme.com": client}}
+
+	current, err := gitProvider.GetProjectMetadata("test-sha", "gitlab.acme.com", "acme/api")
+	assert.NoError(t, err)
+	assert.Equal(t, metadata.Repository{
+		Description:   "API",
+		Topics:        []string{"go"},
+		DefaultBranch: "main",
+		Visibility:    "internal",
+	}, current)
+
+	desired := metadata.Repository{Description: "API", Homepage: "https://acme.com", Topics: []string{"GO"}, Visibility: "private", Archived: true}
+	assert.NoError(t, gitProvider.UpdateProjectMetadata("test-sha", "gitlab.acme.com", "acme/api", current, desired))
+	assert.Equal(t, []string{
+		"PUT /api/v4/projects/acme%2Fapi map[visibility:private]",
+		"POST /api/v4/projects/acme%2Fapi/archive",
+	}, requests)
+}
+
+func TestGitGetGitlab_UpdateProjectMetadata_ArchivedAgainOnError(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"invalid default branch"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := gitlab.NewClient("test-token", gitlab.WithBaseURL(server.URL))
+	require.NoError(t, err)
+	gitProvider := &GitGetGitlab{clients: map[string]*gitlab.Client{"gitlab.acme.com": client}}
+
+	current := metadata.Repository{DefaultBranch: "main", Archived: true}
+	desired := metadata.Repository{DefaultBranch: "missing"}
+	assert.Error(t, gitProvider.UpdateProjectMetadata("test-sha", "gitlab.acme.com", "acme/api", current, desired))
+	assert.Equal(t, []string{
+		"POST /api/v4/projects/acme%2Fapi/unarchive",
+		"PUT /api/v4/projects/acme%2Fapi",
+		"POST /api/v4/projects/acme%2Fapi/archive",
+	}, requests)
 }
 
 func TestGitGetGitlab_ProjectExists(t *testing.T) {
@@ -51,8 +205,56 @@ func TestGitGetGitlab_GetProjectNamespace(t *testing.T) {
 }
 
 func TestGitGetGitlab_CreateProject(t *testing.T) {
-	// This test requires actual GitLab API or mocking at HTTP level
-	t.Skip("Requires GitLab API mocking or integration test")
+	var options map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 7, "path": options["path"], "name": options["name"]})
+	}))
+	defer server.Close()
+
+	client, err := gitlab.NewClient("test-token", gitlab.WithBaseURL(server.URL))
+	require.NoError(t, err)
+	gitProvider := &GitGetGitlab{clients: map[string]*gitlab.Client{"gitlab.acme.com": client}}
+
+	project := gitProvider.CreateProject(
+		"test-sha", "gitlab.acme.com", "My Repo", "my-repo", 42, "private", "https://github.com/acme/My-Repo.git")
+
+	assert.Equal(t, int64(7), project.ID)
+	assert.Equal(t, "My Repo", options["name"])
+	assert.Equal(t, "my-repo", options["path"])
+	assert.Equal(t, float64(42), options["namespace_id"])
+	assert.Equal(t, "private", options["visibility"])
+}
+
+func TestGitGetGitlab_GetNamespaceFullPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/namespaces/42", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 42, "kind": "group", "full_path": "acme/dr/mirrors"})
+	}))
+	defer server.Close()
+
+	client, err := gitlab.NewClient("test-token", gitlab.WithBaseURL(server.URL))
+	require.NoError(t, err)
+	gitProvider := &GitGetGitlab{clients: map[string]*gitlab.Client{"gitlab.acme.com": client}}
+
+	assert.Equal(t, "acme/dr/mirrors", gitProvider.GetNamespaceFullPath("test-sha", "gitlab.acme.com", 42))
+}
+
+func TestPathSlug(t *testing.T) {
+	testCases := map[string]string{
+		"repo":           "repo",
+		"My Repo":        "my-repo",
+		"API_Server.v2":  "api_server.v2",
+		"  Team / Tools": "team-tools",
+		"a -- b":         "a-b",
+		"_hidden.":       "hidden",
+		"mirror.git":     "mirror",
+	}
+
+	for name, expected := range testCases {
+		assert.Equal(t, expected, PathSlug(name), name)
+	}
 }
 
 func TestGitGetGitlab_FetchOwnerRepos(t *testing.T) {
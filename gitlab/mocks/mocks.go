@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"github.com/isindir/git-get/metadata"
 	mock "github.com/stretchr/testify/mock"
 	"gitlab.com/gitlab-org/api/client-go"
 )
@@ -36,17 +37,87 @@ func (_m *GitGetGitlabI) EXPECT() *GitGetGitlabI_Expecter {
 	return &GitGetGitlabI_Expecter{mock: &_m.Mock}
 }
 
+// ConfigurePullMirror provides a mock function for the type GitGetGitlabI
+func (_mock *GitGetGitlabI) ConfigurePullMirror(repositorySha string, baseUrl string, projectNameFullPath string, sourceURL string, authUser string, authPassword string) {
+	_mock.Called(repositorySha, baseUrl, projectNameFullPath, sourceURL, authUser, authPassword)
+	return
+}
+
+// GitGetGitlabI_ConfigurePullMirror_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfigurePullMirror'
+type GitGetGitlabI_ConfigurePullMirror_Call struct {
+	*mock.Call
+}
+
+// ConfigurePullMirror is a helper method to define mock.On call
+//   - repositorySha string
+//   - baseUrl string
+//   - projectNameFullPath string
+//   - sourceURL string
+//   - authUser string
+//   - authPassword string
+func (_e *GitGetGitlabI_Expecter) ConfigurePullMirror(repositorySha interface{}, baseUrl interface{}, projectNameFullPath interface{}, sourceURL interface{}, authUser interface{}, authPassword interface{}) *GitGetGitlabI_ConfigurePullMirror_Call {
+	return &GitGetGitlabI_ConfigurePullMirror_Call{Call: _e.mock.On("ConfigurePullMirror", repositorySha, baseUrl, projectNameFullPath, sourceURL, authUser, authPassword)}
+}
+
+func (_c *GitGetGitlabI_ConfigurePullMirror_Call) Run(run func(repositorySha string, baseUrl string, projectNameFullPath string, sourceURL string, authUser string, authPassword string)) *GitGetGitlabI_ConfigurePullMirror_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGitlabI_ConfigurePullMirror_Call) Return() *GitGetGitlabI_ConfigurePullMirror_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *GitGetGitlabI_ConfigurePullMirror_Call) RunAndReturn(run func(repositorySha string, baseUrl string, projectNameFullPath string, sourceURL string, authUser string, authPassword string)) *GitGetGitlabI_ConfigurePullMirror_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateProject provides a mock function for the type GitGetGitlabI
-func (_mock *GitGetGitlabI) CreateProject(repositorySha string, baseUrl string, projectName string, namespaceID int64, mirrorVisibilityMode string, sourceURL string) *gitlab.Project {
-	ret := _mock.Called(repositorySha, baseUrl, projectName, namespaceID, mirrorVisibilityMode, sourceURL)
+func (_mock *GitGetGitlabI) CreateProject(repositorySha string, baseUrl string, projectName string, projectPath string, namespaceID int64, mirrorVisibilityMode string, sourceURL string) *gitlab.Project {
+	ret := _mock.Called(repositorySha, baseUrl, projectName, projectPath, namespaceID, mirrorVisibilityMode, sourceURL)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateProject")
 	}
 
 	var r0 *gitlab.Project
-	if returnFunc, ok := ret.Get(0).(func(string, string, string, int64, string, string) *gitlab.Project); ok {
-		r0 = returnFunc(repositorySha, baseUrl, projectName, namespaceID, mirrorVisibilityMode, sourceURL)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string, int64, string, string) *gitlab.Project); ok {
+		r0 = returnFunc(repositorySha, baseUrl, projectName, projectPath, namespaceID, mirrorVisibilityMode, sourceURL)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gitlab.Project)
@@ -64,14 +135,15 @@ type GitGetGitlabI_CreateProject_Call struct {
 //   - repositorySha string
 //   - baseUrl string
 //   - projectName string
+//   - projectPath string
 //   - namespaceID int64
 //   - mirrorVisibilityMode string
 //   - sourceURL string
-func (_e *GitGetGitlabI_Expecter) CreateProject(repositorySha interface{}, baseUrl interface{}, projectName interface{}, namespaceID interface{}, mirrorVisibilityMode interface{}, sourceURL interface{}) *GitGetGitlabI_CreateProject_Call {
-	return &GitGetGitlabI_CreateProject_Call{Call: _e.mock.On("CreateProject", repositorySha, baseUrl, projectName, namespaceID, mirrorVisibilityMode, sourceURL)}
+func (_e *GitGetGitlabI_Expecter) CreateProject(repositorySha interface{}, baseUrl interface{}, projectName interface{}, projectPath interface{}, namespaceID interface{}, mirrorVisibilityMode interface{}, sourceURL interface{}) *GitGetGitlabI_CreateProject_Call {
+	return &GitGetGitlabI_CreateProject_Call{Call: _e.mock.On("CreateProject", repositorySha, baseUrl, projectName, projectPath, namespaceID, mirrorVisibilityMode, sourceURL)}
 }
 
-func (_c *GitGetGitlabI_CreateProject_Call) Run(run func(repositorySha string, baseUrl string, projectName string, namespaceID int64, mirrorVisibilityMode string, sourceURL string)) *GitGetGitlabI_CreateProject_Call {
+func (_c *GitGetGitlabI_CreateProject_Call) Run(run func(repositorySha string, baseUrl string, projectName string, projectPath string, namespaceID int64, mirrorVisibilityMode string, sourceURL string)) *GitGetGitlabI_CreateProject_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -85,18 +157,22 @@ func (_c *GitGetGitlabI_CreateProject_Call) Run(run func(repositorySha string, b
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 int64
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].(int64)
+			arg3 = args[3].(string)
 		}
-		var arg4 string
+		var arg4 int64
 		if args[4] != nil {
-			arg4 = args[4].(string)
+			arg4 = args[4].(int64)
 		}
 		var arg5 string
 		if args[5] != nil {
 			arg5 = args[5].(string)
 		}
+		var arg6 string
+		if args[6] != nil {
+			arg6 = args[6].(string)
+		}
 		run(
 			arg0,
 			arg1,
@@ -104,6 +180,7 @@ func (_c *GitGetGitlabI_CreateProject_Call) Run(run func(repositorySha string, b
 			arg3,
 			arg4,
 			arg5,
+			arg6,
 		)
 	})
 	return _c
@@ -114,7 +191,78 @@ func (_c *GitGetGitlabI_CreateProject_Call) Return(project *gitlab.Project) *Git
 	return _c
 }
 
-func (_c *GitGetGitlabI_CreateProject_Call) RunAndReturn(run func(repositorySha string, baseUrl string, projectName string, namespaceID int64, mirrorVisibilityMode string, sourceURL string) *gitlab.Project) *GitGetGitlabI_CreateProject_Call {
+func (_c *GitGetGitlabI_CreateProject_Call) RunAndReturn(run func(repositorySha string, baseUrl string, projectName string, projectPath string, namespaceID int64, mirrorVisibilityMode string, sourceURL string) *gitlab.Project) *GitGetGitlabI_CreateProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureGroupPath provides a mock function for the type GitGetGitlabI
+func (_mock *GitGetGitlabI) EnsureGroupPath(repositorySha string, baseUrl string, groupFullPath string, mirrorVisibilityMode string) *gitlab.Group {
+	ret := _mock.Called(repositorySha, baseUrl, groupFullPath, mirrorVisibilityMode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureGroupPath")
+	}
+
+	var r0 *gitlab.Group
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) *gitlab.Group); ok {
+		r0 = returnFunc(repositorySha, baseUrl, groupFullPath, mirrorVisibilityMode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gitlab.Group)
+		}
+	}
+	return r0
+}
+
+// GitGetGitlabI_EnsureGroupPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureGroupPath'
+type GitGetGitlabI_EnsureGroupPath_Call struct {
+	*mock.Call
+}
+
+// EnsureGroupPath is a helper method to define mock.On call
+//   - repositorySha string
+//   - baseUrl string
+//   - groupFullPath string
+//   - mirrorVisibilityMode string
+func (_e *GitGetGitlabI_Expecter) EnsureGroupPath(repositorySha interface{}, baseUrl interface{}, groupFullPath interface{}, mirrorVisibilityMode interface{}) *GitGetGitlabI_EnsureGroupPath_Call {
+	return &GitGetGitlabI_EnsureGroupPath_Call{Call: _e.mock.On("EnsureGroupPath", repositorySha, baseUrl, groupFullPath, mirrorVisibilityMode)}
+}
+
+func (_c *GitGetGitlabI_EnsureGroupPath_Call) Run(run func(repositorySha string, baseUrl string, groupFullPath string, mirrorVisibilityMode string)) *GitGetGitlabI_EnsureGroupPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGitlabI_EnsureGroupPath_Call) Return(group *gitlab.Group) *GitGetGitlabI_EnsureGroupPath_Call {
+	_c.Call.Return(group)
+	return _c
+}
+
+func (_c *GitGetGitlabI_EnsureGroupPath_Call) RunAndReturn(run func(repositorySha string, baseUrl string, groupFullPath string, mirrorVisibilityMode string) *gitlab.Group) *GitGetGitlabI_EnsureGroupPath_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -202,6 +350,141 @@ func (_c *GitGetGitlabI_FetchOwnerRepos_Call) RunAndReturn(run func(repositorySh
 	return _c
 }
 
+// GetNamespaceFullPath provides a mock function for the type GitGetGitlabI
+func (_mock *GitGetGitlabI) GetNamespaceFullPath(repositorySha string, baseUrl string, namespaceID int64) string {
+	ret := _mock.Called(repositorySha, baseUrl, namespaceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamespaceFullPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(string, string, int64) string); ok {
+		r0 = returnFunc(repositorySha, baseUrl, namespaceID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// GitGetGitlabI_GetNamespaceFullPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNamespaceFullPath'
+type GitGetGitlabI_GetNamespaceFullPath_Call struct {
+	*mock.Call
+}
+
+// GetNamespaceFullPath is a helper method to define mock.On call
+//   - repositorySha string
+//   - baseUrl string
+//   - namespaceID int64
+func (_e *GitGetGitlabI_Expecter) GetNamespaceFullPath(repositorySha interface{}, baseUrl interface{}, namespaceID interface{}) *GitGetGitlabI_GetNamespaceFullPath_Call {
+	return &GitGetGitlabI_GetNamespaceFullPath_Call{Call: _e.mock.On("GetNamespaceFullPath", repositorySha, baseUrl, namespaceID)}
+}
+
+func (_c *GitGetGitlabI_GetNamespaceFullPath_Call) Run(run func(repositorySha string, baseUrl string, namespaceID int64)) *GitGetGitlabI_GetNamespaceFullPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGitlabI_GetNamespaceFullPath_Call) Return(s string) *GitGetGitlabI_GetNamespaceFullPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *GitGetGitlabI_GetNamespaceFullPath_Call) RunAndReturn(run func(repositorySha string, baseUrl string, namespaceID int64) string) *GitGetGitlabI_GetNamespaceFullPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProjectMetadata provides a mock function for the type GitGetGitlabI
+func (_mock *GitGetGitlabI) GetProjectMetadata(repositorySha string, baseUrl string, projectNameFullPath string) (metadata.Repository, error) {
+	ret := _mock.Called(repositorySha, baseUrl, projectNameFullPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectMetadata")
+	}
+
+	var r0 metadata.Repository
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) (metadata.Repository, error)); ok {
+		return returnFunc(repositorySha, baseUrl, projectNameFullPath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) metadata.Repository); ok {
+		r0 = returnFunc(repositorySha, baseUrl, projectNameFullPath)
+	} else {
+		r0 = ret.Get(0).(metadata.Repository)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = returnFunc(repositorySha, baseUrl, projectNameFullPath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// GitGetGitlabI_GetProjectMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProjectMetadata'
+type GitGetGitlabI_GetProjectMetadata_Call struct {
+	*mock.Call
+}
+
+// GetProjectMetadata is a helper method to define mock.On call
+//   - repositorySha string
+//   - baseUrl string
+//   - projectNameFullPath string
+func (_e *GitGetGitlabI_Expecter) GetProjectMetadata(repositorySha interface{}, baseUrl interface{}, projectNameFullPath interface{}) *GitGetGitlabI_GetProjectMetadata_Call {
+	return &GitGetGitlabI_GetProjectMetadata_Call{Call: _e.mock.On("GetProjectMetadata", repositorySha, baseUrl, projectNameFullPath)}
+}
+
+func (_c *GitGetGitlabI_GetProjectMetadata_Call) Run(run func(repositorySha string, baseUrl string, projectNameFullPath string)) *GitGetGitlabI_GetProjectMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGitlabI_GetProjectMetadata_Call) Return(repository metadata.Repository, err error) *GitGetGitlabI_GetProjectMetadata_Call {
+	_c.Call.Return(repository, err)
+	return _c
+}
+
+func (_c *GitGetGitlabI_GetProjectMetadata_Call) RunAndReturn(run func(repositorySha string, baseUrl string, projectNameFullPath string) (metadata.Repository, error)) *GitGetGitlabI_GetProjectMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProjectNamespace provides a mock function for the type GitGetGitlabI
 func (_mock *GitGetGitlabI) GetProjectNamespace(repositorySha string, baseUrl string, projectNameFullPath string) (*gitlab.Namespace, string) {
 	ret := _mock.Called(repositorySha, baseUrl, projectNameFullPath)
@@ -320,6 +603,57 @@ func (_c *GitGetGitlabI_Init_Call) RunAndReturn(run func() bool) *GitGetGitlabI_
 	return _c
 }
 
+// InitForHost provides a mock function for the type GitGetGitlabI
+func (_mock *GitGetGitlabI) InitForHost(host string) bool {
+	ret := _mock.Called(host)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitForHost")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(host)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// GitGetGitlabI_InitForHost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InitForHost'
+type GitGetGitlabI_InitForHost_Call struct {
+	*mock.Call
+}
+
+// InitForHost is a helper method to define mock.On call
+//   - host string
+func (_e *GitGetGitlabI_Expecter) InitForHost(host interface{}) *GitGetGitlabI_InitForHost_Call {
+	return &GitGetGitlabI_InitForHost_Call{Call: _e.mock.On("InitForHost", host)}
+}
+
+func (_c *GitGetGitlabI_InitForHost_Call) Run(run func(host string)) *GitGetGitlabI_InitForHost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGitlabI_InitForHost_Call) Return(b bool) *GitGetGitlabI_InitForHost_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *GitGetGitlabI_InitForHost_Call) RunAndReturn(run func(host string) bool) *GitGetGitlabI_InitForHost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ProjectExists provides a mock function for the type GitGetGitlabI
 func (_mock *GitGetGitlabI) ProjectExists(repositorySha string, baseUrl string, projectName string) bool {
 	ret := _mock.Called(repositorySha, baseUrl, projectName)
@@ -383,6 +717,81 @@ func (_c *GitGetGitlabI_ProjectExists_Call) RunAndReturn(run func(repositorySha
 	return _c
 }
 
+// UpdateProjectMetadata provides a mock function for the type GitGetGitlabI
+func (_mock *GitGetGitlabI) UpdateProjectMetadata(repositorySha string, baseUrl string, projectNameFullPath string, current metadata.Repository, desired metadata.Repository) error {
+	ret := _mock.Called(repositorySha, baseUrl, projectNameFullPath, current, desired)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProjectMetadata")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, metadata.Repository, metadata.Repository) error); ok {
+		r0 = returnFunc(repositorySha, baseUrl, projectNameFullPath, current, desired)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// GitGetGitlabI_UpdateProjectMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProjectMetadata'
+type GitGetGitlabI_UpdateProjectMetadata_Call struct {
+	*mock.Call
+}
+
+// UpdateProjectMetadata is a helper method to define mock.On call
+//   - repositorySha string
+//   - baseUrl string
+//   - projectNameFullPath string
+//   - current metadata.Repository
+//   - desired metadata.Repository
+func (_e *GitGetGitlabI_Expecter) UpdateProjectMetadata(repositorySha interface{}, baseUrl interface{}, projectNameFullPath interface{}, current interface{}, desired interface{}) *GitGetGitlabI_UpdateProjectMetadata_Call {
+	return &GitGetGitlabI_UpdateProjectMetadata_Call{Call: _e.mock.On("UpdateProjectMetadata", repositorySha, baseUrl, projectNameFullPath, current, desired)}
+}
+
+func (_c *GitGetGitlabI_UpdateProjectMetadata_Call) Run(run func(repositorySha string, baseUrl string, projectNameFullPath string, current metadata.Repository, desired metadata.Repository)) *GitGetGitlabI_UpdateProjectMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 metadata.Repository
+		if args[3] != nil {
+			arg3 = args[3].(metadata.Repository)
+		}
+		var arg4 metadata.Repository
+		if args[4] != nil {
+			arg4 = args[4].(metadata.Repository)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *GitGetGitlabI_UpdateProjectMetadata_Call) Return(err error) *GitGetGitlabI_UpdateProjectMetadata_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *GitGetGitlabI_UpdateProjectMetadata_Call) RunAndReturn(run func(repositorySha string, baseUrl string, projectNameFullPath string, current metadata.Repository, desired metadata.Repository) error) *GitGetGitlabI_UpdateProjectMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // appendGroupsProjects provides a mock function for the type GitGetGitlabI
 func (_mock *GitGetGitlabI) appendGroupsProjects(repoSha string, git *gitlab.Client, groupID int64, groupName string, glRepoList []*gitlab.Project, gitlabOwned bool, gitlabVisibility string) []*gitlab.Project {
 	ret := _mock.Called(repoSha, git, groupID, groupName, glRepoList, gitlabOwned, gitlabVisibility)
@@ -23,6 +23,8 @@ require (
 	golang.org/x/net v0.48.0 // indirect
 	// https://pkg.go.dev/golang.org/x/oauth2
 	golang.org/x/oauth2 v0.34.0
+	// https://pkg.go.dev/golang.org/x/sys
+	golang.org/x/sys v0.39.0
 	// https://gopkg.in/yaml.v3
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -40,6 +42,5 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
-	golang.org/x/sys v0.39.0 // indirect
 	golang.org/x/time v0.14.0 // indirect
 )
@@ -0,0 +1,65 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package metadata defines git provider independent repository metadata, which is synchronised
+// from source repositories to their mirrors.
+package metadata
+
+import (
+	"sort"
+	"strings"
+)
+
+// Repository - repository metadata, providers ignore fields they don't support
+type Repository struct {
+	Description   string
+	Homepage      string
+	Topics        []string
+	DefaultBranch string
+	Visibility    string // private, internal or public
+	Archived      bool
+}
+
+// SameTopics - returns true if both lists contain the same topics regardless of order and case
+func SameTopics(topics, otherTopics []string) bool {
+	if len(topics) != len(otherTopics) {
+		return false
+	}
+
+	normalise := func(list []string) []string {
+		result := make([]string, 0, len(list))
+		for _, topic := range list {
+			result = append(result, strings.ToLower(topic))
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	sorted, otherSorted := normalise(topics), normalise(otherTopics)
+	for i := range sorted {
+		if sorted[i] != otherSorted[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,17 @@
+//go:build !integration
+// +build !integration
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSameTopics(t *testing.T) {
+	assert.True(t, SameTopics(nil, []string{}))
+	assert.True(t, SameTopics([]string{"go", "CLI"}, []string{"cli", "go"}))
+	assert.False(t, SameTopics([]string{"go"}, []string{"go", "cli"}))
+	assert.False(t, SameTopics([]string{"go", "git"}, []string{"go", "cli"}))
+}
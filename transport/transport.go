@@ -0,0 +1,136 @@
+/*
+Copyright © 2026 Eriks Zelenka <isindir@users.sourceforge.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package transport provides HTTP transport shared by git provider API clients, which keeps
+// connections to provider hosts open between calls and counts API calls made during the run.
+package transport
+
+import (
+	"crypto/tls"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// maxIdleConnsPerHost - keep enough idle connections for concurrent repository processing
+const maxIdleConnsPerHost = 32
+
+// Counter - number of requests per host, safe for concurrent use
+type Counter struct {
+	mutex  sync.Mutex
+	counts map[string]int
+}
+
+// CountingTransport - http.RoundTripper counting requests per host in Counter
+type CountingTransport struct {
+	Base    http.RoundTripper
+	Counter *Counter
+}
+
+// HostCount - number of API calls made to the host
+type HostCount struct {
+	Host  string
+	Calls int
+}
+
+var (
+	pooledTransport = newPooledTransport()
+	defaultCounter  = &Counter{}
+)
+
+func newPooledTransport() *http.Transport {
+	pooled := http.DefaultTransport.(*http.Transport).Clone()
+	pooled.MaxIdleConnsPerHost = maxIdleConnsPerHost
+
+	return pooled
+}
+
+// Add - counts single request to the host
+func (counter *Counter) Add(host string) {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	if counter.counts == nil {
+		counter.counts = map[string]int{}
+	}
+	counter.counts[host]++
+}
+
+// Counts - returns number of requests per host sorted by host name
+func (counter *Counter) Counts() []HostCount {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	result := make([]HostCount, 0, len(counter.counts))
+	for host, calls := range counter.counts {
+		result = append(result, HostCount{Host: host, Calls: calls})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Host < result[j].Host })
+
+	return result
+}
+
+// Total - returns number of requests made to all hosts
+func (counter *Counter) Total() int {
+	total := 0
+	for _, hostCount := range counter.Counts() {
+		total += hostCount.Calls
+	}
+
+	return total
+}
+
+// RoundTrip - counts request and passes it to the base transport
+func (transport *CountingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport.Counter.Add(req.URL.Host)
+
+	base := transport.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return base.RoundTrip(req)
+}
+
+// Client - returns HTTP client using shared pooled and counting transport
+func Client() *http.Client {
+	return &http.Client{Transport: &CountingTransport{Base: pooledTransport, Counter: defaultCounter}}
+}
+
+// ClientWithTLS - returns HTTP client with custom TLS configuration, its requests are counted
+// together with requests of other clients of this package
+func ClientWithTLS(tlsConfig *tls.Config) *http.Client {
+	pooled := newPooledTransport()
+	pooled.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: &CountingTransport{Base: pooled, Counter: defaultCounter}}
+}
+
+// Counts - returns number of API calls per host made by all clients of this package
+func Counts() []HostCount {
+	return defaultCounter.Counts()
+}
+
+// Total - returns number of API calls made by all clients of this package
+func Total() int {
+	return defaultCounter.Total()
+}
@@ -0,0 +1,64 @@
+//go:build !integration
+// +build !integration
+
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	counter := &Counter{}
+	client := &http.Client{Transport: &CountingTransport{Base: newPooledTransport(), Counter: counter}}
+
+	var wait sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			res, err := client.Get(server.URL)
+			if assert.NoError(t, err) {
+				res.Body.Close()
+			}
+		}()
+	}
+	wait.Wait()
+
+	assert.Equal(t, []HostCount{{Host: host, Calls: 10}}, counter.Counts())
+	assert.Equal(t, 10, counter.Total())
+}
+
+func TestClient_CountsIntoDefaultCounter(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	before := Total()
+
+	res, err := Client().Get(server.URL)
+	assert.Error(t, err, "default client does not trust test server certificate")
+	if res != nil {
+		res.Body.Close()
+	}
+
+	tlsClient := ClientWithTLS(server.Client().Transport.(*http.Transport).TLSClientConfig)
+	res, err = tlsClient.Get(server.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	assert.Equal(t, before+2, Total())
+}